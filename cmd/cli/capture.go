@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"log"
+	"time"
+)
+
+var (
+	asOf string
+	// captureTime is the time ages are computed against; zero means now.
+	captureTime time.Time
+)
+
+// resolveCaptureTime sets captureTime from --as-of, or else from the dump,
+// see dump.Dump.CaptureTime.
+func resolveCaptureTime() {
+	if len(asOf) > 0 {
+		t, err := parseAsOf(asOf)
+		if err != nil {
+			log.Fatalf("Invalid --as-of time %s, use RFC 3339 such as 2024-05-01T10:00:00Z: %v", asOf, err.Error())
+		}
+		captureTime = t
+		return
+	}
+	captureTime = dumpData.CaptureTime()
+}
+
+func parseAsOf(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// referenceTime is the time ages are computed against.
+func referenceTime() time.Time {
+	if captureTime.IsZero() {
+		return time.Now()
+	}
+	return captureTime
+}
@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shundezhang/kubedmp/pkg/dump"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	. "k8s.io/kubectl/pkg/describe"
+)
+
+var (
+	// client is a fake clientset holding the objects of the dump client-go
+	// has a type for, read by kind as they are asked for, see loadKind.
+	client *fake.Clientset
+	// resourceKinds maps the resources client serves to their kinds.
+	resourceKinds map[schema.GroupVersionResource]schema.GroupVersionKind
+	// loadedKinds are the kinds read into client.
+	loadedKinds map[string]bool
+	// ageOffset is the time since the dump was taken, see shiftTimes.
+	ageOffset time.Duration
+)
+
+// describerFor returns the describer kubectl describe uses for kind. It reads
+// the object and the objects related to it, such as its events or the pods
+// on a node, from the dump through dumpTransport.
+func describerFor(kind schema.GroupKind) (ResourceDescriber, bool) {
+	dumpClient()
+	return DescriberFor(kind, &rest.Config{Host: "http://kubedmp", Transport: dumpTransport{}})
+}
+
+// dumpClient creates client on first use. It starts empty; dumpTransport
+// loads the kinds a request is for.
+func dumpClient() *fake.Clientset {
+	if client != nil {
+		return client
+	}
+	client = fake.NewSimpleClientset()
+	resourceKinds = map[schema.GroupVersionResource]schema.GroupVersionKind{}
+	for gvk := range scheme.Scheme.AllKnownTypes() {
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		resourceKinds[gvr] = gvk
+	}
+	for _, k := range dump.Kinds() {
+		if scheme.Scheme.Recognizes(k.GroupVersionKind()) {
+			resourceKinds[k.GroupVersionKind().GroupVersion().WithResource(k.Resource)] = k.GroupVersionKind()
+		}
+	}
+	loadedKinds = map[string]bool{}
+	ageOffset = time.Since(referenceTime())
+	client.PrependReactor("list", "*", listWithSelectors)
+	return client
+}
+
+// loadKind reads the objects of kind from the dump into client once.
+func loadKind(kind string) {
+	if loadedKinds[kind] {
+		return
+	}
+	loadedKinds[kind] = true
+	objects, err := dumpData.List(kind, "", nil, nil)
+	if err != nil {
+		log.Fatalf("Error to read dump: %v", err.Error())
+	}
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		typed, err := scheme.Scheme.New(gvk)
+		if err != nil {
+			continue
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, typed); err != nil {
+			continue
+		}
+		shiftTimes(typed, ageOffset)
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		if k, ok := dumpData.LookupKind(gvk.Kind); ok {
+			gvr = gvk.GroupVersion().WithResource(k.Resource)
+			resourceKinds[gvr] = gvk
+		}
+		// objects found twice, such as namespaces, are added once
+		client.Tracker().Create(gvr, typed, obj.GetNamespace())
+	}
+}
+
+// shiftTimes moves the times of obj that the describers show as ages, such
+// as those of events, by offset, the time since the dump was taken. The
+// describers compute ages against the current time; shifted, the ages are
+// those at the time of the dump, as get shows them. Times the describers
+// print as dates are left alone.
+func shiftTimes(obj runtime.Object, offset time.Duration) {
+	shift := func(t *metav1.Time) {
+		if t != nil && !t.IsZero() {
+			t.Time = t.Add(offset)
+		}
+	}
+	if accessor, err := meta.Accessor(obj); err == nil && accessor.GetDeletionTimestamp() != nil {
+		deletion := accessor.GetDeletionTimestamp().DeepCopy()
+		shift(deletion)
+		accessor.SetDeletionTimestamp(deletion)
+	}
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		// the age of the pods of a node
+		shift(&o.CreationTimestamp)
+	case *corev1.Event:
+		shift(&o.FirstTimestamp)
+		shift(&o.LastTimestamp)
+		if !o.EventTime.IsZero() {
+			o.EventTime.Time = o.EventTime.Add(offset)
+		}
+		if o.Series != nil && !o.Series.LastObservedTime.IsZero() {
+			o.Series.LastObservedTime.Time = o.Series.LastObservedTime.Add(offset)
+		}
+	}
+}
+
+// listWithSelectors lists the objects of a list action that match its label
+// and field selectors, which the fake clientset leaves to its typed clients.
+func listWithSelectors(action k8stesting.Action) (bool, runtime.Object, error) {
+	list, ok := action.(k8stesting.ListActionImpl)
+	if !ok {
+		return false, nil, nil
+	}
+	obj, err := client.Tracker().List(list.GetResource(), list.GetKind(), list.GetNamespace())
+	if err != nil {
+		return true, nil, err
+	}
+	items, err := meta.ExtractList(obj)
+	if err != nil {
+		return true, nil, err
+	}
+	restrictions := list.GetListRestrictions()
+	matched := []runtime.Object{}
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			continue
+		}
+		if restrictions.Labels != nil && !restrictions.Labels.Matches(labels.Set(accessor.GetLabels())) {
+			continue
+		}
+		if restrictions.Fields != nil && !restrictions.Fields.Empty() {
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(item)
+			if err != nil {
+				continue
+			}
+			itemFields := fields.Set{}
+			for _, requirement := range restrictions.Fields.Requirements() {
+				itemFields[requirement.Field] = dump.FieldValue(content, requirement.Field)
+			}
+			if !restrictions.Fields.Matches(itemFields) {
+				continue
+			}
+		}
+		matched = append(matched, item)
+	}
+	// the tracker keeps objects in a map; list them in a stable order
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, _ := meta.Accessor(matched[i])
+		b, _ := meta.Accessor(matched[j])
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+	return true, obj, meta.SetList(obj, matched)
+}
+
+// dumpTransport answers the GET requests of a client of the API server from
+// client, loading the kind asked for from the dump first, so that code
+// written for a cluster, such as the describers of kubectl, reads the dump
+// instead.
+type dumpTransport struct{}
+
+func (dumpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	gvr, namespace, name := parseAPIPath(req.URL.Path)
+	gvk, ok := resourceKinds[gvr]
+	if req.Method != http.MethodGet || !ok {
+		return statusResponse(req, apierrors.NewNotFound(gvr.GroupResource(), name))
+	}
+	loadKind(gvk.Kind)
+	var obj runtime.Object
+	var err error
+	if len(name) > 0 {
+		obj, err = client.Invokes(k8stesting.NewGetAction(gvr, namespace, name), nil)
+	} else {
+		query := req.URL.Query()
+		opts := metav1.ListOptions{LabelSelector: query.Get("labelSelector"), FieldSelector: query.Get("fieldSelector")}
+		obj, err = client.Invokes(k8stesting.NewListAction(gvr, gvk, namespace, opts), nil)
+		gvk.Kind += "List"
+	}
+	if err != nil {
+		return statusResponse(req, err)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	return jsonResponse(req, http.StatusOK, obj)
+}
+
+// parseAPIPath splits the path of a request, such as
+// /apis/apps/v1/namespaces/default/deployments/web, into the resource,
+// namespace and name asked for.
+func parseAPIPath(path string) (schema.GroupVersionResource, string, string) {
+	gvr := schema.GroupVersionResource{}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		gvr.Version, parts = parts[1], parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		gvr.Group, gvr.Version, parts = parts[1], parts[2], parts[3:]
+	default:
+		return gvr, "", ""
+	}
+	namespace := ""
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		namespace, parts = parts[1], parts[2:]
+	}
+	name := ""
+	if len(parts) > 0 {
+		gvr.Resource = parts[0]
+	}
+	if len(parts) > 1 {
+		name = parts[1]
+	}
+	return gvr, namespace, name
+}
+
+func statusResponse(req *http.Request, err error) (*http.Response, error) {
+	status, ok := err.(apierrors.APIStatus)
+	if !ok {
+		status = apierrors.NewInternalError(err)
+	}
+	s := status.Status()
+	s.APIVersion, s.Kind = "v1", "Status"
+	return jsonResponse(req, int(s.Code), &s)
+}
+
+func jsonResponse(req *http.Request, code int, obj interface{}) (*http.Response, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: code,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/kubectl/pkg/cmd/get"
+)
+
+var (
+	sortBy       string
+	showLabels   bool
+	labelColumns []string
+)
+
+// labelHeaders and labelValues end the rows of a list with the -L label
+// columns and, with --show-labels, all labels of each object.
+func labelHeaders() string {
+	headers := ""
+	for _, key := range labelColumns {
+		parts := strings.Split(key, "/")
+		headers += "\t" + strings.ToUpper(parts[len(parts)-1])
+	}
+	if showLabels {
+		headers += "\tLABELS"
+	}
+	return headers
+}
+
+func labelValues(item interface{}) string {
+	if len(labelColumns) == 0 && !showLabels {
+		return ""
+	}
+	data, _ := item.(map[string]interface{})
+	metadata, _ := data["metadata"].(map[string]interface{})
+	labels, _ := metadata["labels"].(map[string]interface{})
+	values := ""
+	for _, key := range labelColumns {
+		value, _ := labels[key].(string)
+		values += "\t" + value
+	}
+	if showLabels {
+		pairs := []string{}
+		for k, v := range labels {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", k, v))
+		}
+		sort.Strings(pairs)
+		values += "\t" + orNone(strings.Join(pairs, ","))
+	}
+	return values
+}
+
+// sortItems sorts items by the field given with --sort-by, a JSONPath
+// expression such as .status.containerStatuses[0].restartCount. Numbers and
+// quantities such as 10Gi are compared by value; objects without the field
+// come first.
+func sortItems(items []interface{}) {
+	if len(sortBy) == 0 {
+		return
+	}
+	expression, err := get.RelaxedJSONPathExpression(sortBy)
+	if err != nil {
+		log.Fatalf("Error parsing --sort-by %s: %v", sortBy, err.Error())
+	}
+	path := jsonpath.New("sort-by").AllowMissingKeys(true)
+	if err := path.Parse(expression); err != nil {
+		log.Fatalf("Error parsing --sort-by %s: %v", sortBy, err.Error())
+	}
+	keys := map[int]interface{}{}
+	for i, item := range items {
+		results, err := path.FindResults(item)
+		if err != nil || len(results) == 0 || len(results[0]) == 0 {
+			continue
+		}
+		if value := results[0][0]; value.IsValid() && value.CanInterface() {
+			keys[i] = value.Interface()
+		}
+	}
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return lessValue(keys[order[i]], keys[order[j]])
+	})
+	sorted := make([]interface{}, len(items))
+	for i, index := range order {
+		sorted[i] = items[index]
+	}
+	copy(items, sorted)
+}
+
+func lessValue(a interface{}, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			return x < y
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			return !x && y
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			qx, errX := apiresource.ParseQuantity(x)
+			qy, errY := apiresource.ParseQuantity(y)
+			if errX == nil && errY == nil {
+				return qx.Cmp(qy) < 0
+			}
+			return x < y
+		}
+	}
+	return reflect.TypeOf(a).String() < reflect.TypeOf(b).String()
+}
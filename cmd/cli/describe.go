@@ -1,13 +1,10 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"os"
-	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 	"text/tabwriter"
 
@@ -18,596 +15,186 @@ import (
 	"io"
 
 	"github.com/spf13/cobra"
-	appsv1 "k8s.io/api/apps/v1"
-	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
-	networkingv1 "k8s.io/api/networking/v1"
-	rbacv1 "k8s.io/api/rbac/v1"
-	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 	. "k8s.io/kubectl/pkg/describe"
-	"k8s.io/kubectl/pkg/util/rbac"
 )
 
 var (
 	// globally skipped annotations
 	skipAnnotations  = sets.NewString(corev1.LastAppliedConfigAnnotation)
 	maxAnnotationLen = 140
+
+	describePrintFlags *genericclioptions.PrintFlags
 )
 
 var describeCmd = &cobra.Command{
-	Use:                   "describe TYPE RESOURCE_NAME [-n NAMESPACE]",
+	Use:                   "describe TYPE [NAME_PREFIX... | -l label] [-n NAMESPACE | -A]",
 	DisableFlagsInUseLine: true,
-	Short:                 "Show details of a specific resource",
-	Long:                  `Show details of a specific resource. Print a detailed description of the selected resource.`,
+	Short:                 "Show details of a specific resource or group of resources",
+	Long: `Show details of a specific resource or group of resources. Print a detailed description of the selected resources.
+
+An object is first looked up by its exact name; if there is none, all objects whose name starts with the
+given name are described. Without a name or a selector, every object of the type is described.`,
 	Example: `  # Describe a node
   $ kubedmp describe no juju-ceba75-k8s-2
   
   # Describe a pod in kube-system namespace
-  $ kubedmp describe po coredns-6bcf44f4cc-j9wkq -n kube-system`,
+  $ kubedmp describe po coredns-6bcf44f4cc-j9wkq -n kube-system
+
+  # Describe the pods whose names start with web-
+  $ kubedmp describe po web-
+
+  # Describe the pods of app web
+  $ kubedmp describe po -l app=web
+
+  # Describe all pods in all namespaces
+  $ kubedmp describe po -A`,
 	// Args:    cobra.MinimumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 
-		if len(args) < 2 {
-			log.Fatalf("Please specify a type and an object name\n")
+		if len(args) == 0 {
+			log.Fatalf("Please specify a type\n")
 			return
 		}
 		resType = args[0]
-		resName = args[1]
+		names := args[1:]
 
 		var err error
-		resKind, err = getKind(resType)
+		openDump()
+		resource, err = lookupType(resType)
 		if err != nil {
-			log.Fatalf("%s is not a supported resource type.\n", resType)
+			log.Fatalf("Error: %v\n", err.Error())
 			return
 		}
-		if contains(UnnamespacedTypes, resKind) {
-			resNamespace = ""
+		var missing []string
+		displayItems, missing = describeItems(names)
+		resName = ""
+		if len(names) == 1 && len(displayItems) == 1 {
+			resName = names[0]
 		}
-		filePath := dumpFile
-		if len(dumpDir) > 0 {
-			dumpDirPath, _ := filepath.Abs(dumpDir)
-			// fmt.Println("fullPath: ", dumpDirPath)
-			filename := DumpFileNames[resKind]
-			if strings.Contains(dumpDirPath, "sos_commands") && strings.Contains(dumpDirPath, "kubernetes") {
-				subdirs, err1 := os.ReadDir(dumpDir)
-				if err1 != nil {
-					log.Fatalf("Error to open [dir=%v]: %v", dumpDir, err1.Error())
-				}
-				for _, dir := range subdirs {
-					if contains(UnnamespacedTypes, resKind) && strings.HasSuffix(dir.Name(), "_get_-o_json_"+filename) {
-						filePath = filepath.Join(dumpDir, dir.Name())
-						break
-					}
-					if dir.IsDir() && dir.Name() == resNamespace {
-						resFiles, err2 := os.ReadDir(filepath.Join(dumpDir, dir.Name()))
-						if err2 != nil {
-							log.Fatalf("Error to open [dir=%v]: %v", dir.Name(), err2.Error())
-						}
-						// fmt.Println("subdirInfo.Name(): ", subdirInfo.Name())
-						for _, resFile := range resFiles {
-							if strings.HasSuffix(resFile.Name(), "_get_-o_json_--namespace_"+resNamespace+"_"+filename) {
-								filePath = filepath.Join(dumpDir, dir.Name(), resFile.Name())
-								break
-							}
-						}
-						break
-					}
+		if !printObjects(describePrintFlags, displayItems) {
+			resolveCaptureTime()
+			for i, item := range displayItems {
+				if i > 0 {
+					fmt.Println("================================================")
+					fmt.Println()
 				}
-			} else {
-				filePath = filepath.Join(dumpDir, resNamespace, filename+"."+dumpFormat)
+				describeObject(item)
 			}
 		}
-		// fmt.Println("filePath: ", filePath)
-		readFile(filePath, describeObject)
-
+		for _, m := range missing {
+			log.Println(m)
+		}
+		if len(missing) > 0 {
+			os.Exit(1)
+		}
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(describeCmd)
 	describeCmd.Flags().StringVarP(&resNamespace, ns, "n", "default", "namespace of the resource, not applicable to node")
-	describeCmd.PersistentFlags().StringVarP(&dumpFile, dumpFileFlag, "f", "./cluster-info.dump", "Path to dump file")
-	describeCmd.PersistentFlags().StringVarP(&dumpDir, dumpDirFlag, "d", "", "Path to dump directory")
+	describeCmd.Flags().BoolVarP(&allNamespaces, an, "A", false, "If present, describe the requested object(s) across all namespaces.")
+	describePrintFlags = addOutputFlags(describeCmd)
+	addSelectorFlags(describeCmd)
+	addDumpFlags(describeCmd)
+	describeCmd.Flags().StringVar(&asOf, "as-of", "", "Compute ages against this RFC 3339 time instead of when the dump was taken.")
 
 }
 
-func tabbedString(f func(io.Writer) error) (string, error) {
-	out := new(tabwriter.Writer)
-	buf := &bytes.Buffer{}
-	out.Init(buf, 0, 8, 2, ' ', 0)
-
-	err := f(out)
-	if err != nil {
-		return "", err
+// describeItems returns the objects of resource to describe: for each name,
+// the object with that name or else the objects whose names start with it,
+// or, without names, every object matching the selectors. It also returns a
+// line for each name nothing was found for, which makes describe fail.
+func describeItems(names []string) ([]interface{}, []string) {
+	namespace := resNamespace
+	if allNamespaces {
+		namespace = ""
 	}
-
-	out.Flush()
-	return buf.String(), nil
-}
-
-func describeObject(buffer string) {
-	var result map[string]interface{}
-	// fmt.Println(buffer)
-	// fmt.Println(resType, resNamespace, resName)
-	err := json.Unmarshal([]byte(buffer), &result)
-
+	if len(names) > 0 && hasSelector() {
+		log.Fatalf("Error: name cannot be provided when a selector is specified.")
+	}
+	labelSel, fieldSel := selectors()
+	objects, err := dumpData.List(resource.Kind.Kind, namespace, labelSel, fieldSel)
 	if err != nil {
-		// log.Fatalf("Error processing buffer: %v\n%v\n", err.Error(), buffer)
-		return
+		log.Fatalf("Error to read dump: %v", err.Error())
 	}
-	// fmt.Println(result["kind"].(string))
-	if kind, ok := result["kind"].(string); ok {
-
-		if inType(resType, "Node") && (kind == "NodeList" || kind == "List") {
-			var nodeList corev1.NodeList
-			err := json.Unmarshal([]byte(buffer), &nodeList)
-			if err != nil {
-				log.Fatalf("Error parsing node list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, node := range nodeList.Items {
-				// fmt.Println("Checking ", node.Name)
-				if resName == node.Name {
-
-					s, err := DefaultObjectDescriber.DescribeObject(&node)
-					if err != nil {
-						log.Fatalf("Error generating output for node %s: %s", node.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "PersistentVolume") && (kind == "PersistentVolumeList" || kind == "List") {
-			var pvList corev1.PersistentVolumeList
-			err := json.Unmarshal([]byte(buffer), &pvList)
-			if err != nil {
-				log.Fatalf("Error parsing pv list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, pv := range pvList.Items {
-				if resName == pv.Name {
-					s, err := DefaultObjectDescriber.DescribeObject(&pv)
-					if err != nil {
-						log.Fatalf("Error generating output for pv %s: %s", pv.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "StorageClass") && (kind == "StorageClassList" || kind == "List") {
-			var scList storagev1.StorageClassList
-			err := json.Unmarshal([]byte(buffer), &scList)
-			if err != nil {
-				log.Fatalf("Error parsing sc list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, sc := range scList.Items {
-				if resName == sc.Name {
-					s, err := DefaultObjectDescriber.DescribeObject(&sc)
-					if err != nil {
-						log.Fatalf("Error generating output for sc %s: %s", sc.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "ClusterRole") && (kind == "ClusterRoleList" || kind == "List") {
-			var crList rbacv1.ClusterRoleList
-			err := json.Unmarshal([]byte(buffer), &crList)
-			if err != nil {
-				log.Fatalf("Error parsing cluster role list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, cr := range crList.Items {
-				if resName == cr.Name {
-					s, err := describeClusterRole(&cr)
-					if err != nil {
-						log.Fatalf("Error generating output for cluster role %s: %s", cr.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "ClusterRoleBinding") && (kind == "ClusterRoleBindingList" || kind == "List") {
-			var crbList rbacv1.ClusterRoleBindingList
-			err := json.Unmarshal([]byte(buffer), &crbList)
-			if err != nil {
-				log.Fatalf("Error parsing cluster role binding list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, crb := range crbList.Items {
-				if resName == crb.Name {
-					s, err := describeClusterRoleBinding(&crb)
-					if err != nil {
-						log.Fatalf("Error generating output for cluster role binding %s: %s", crb.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "Pod") && (kind == "PodList" || kind == "List") {
-			var podList corev1.PodList
-			err := json.Unmarshal([]byte(buffer), &podList)
-			if err != nil {
-				log.Fatalf("Error parsing pod list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, pod := range podList.Items {
-				if resName == pod.Name && resNamespace == pod.Namespace {
-					s, err := DefaultObjectDescriber.DescribeObject(&pod)
-					if err != nil {
-						log.Fatalf("Error generating output for pod %s/%s: %s", pod.Namespace, pod.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "Service") && (kind == "ServiceList" || kind == "List") {
-			var svcList corev1.ServiceList
-			err := json.Unmarshal([]byte(buffer), &svcList)
-			if err != nil {
-				log.Fatalf("Error parsing service list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, svc := range svcList.Items {
-				if resName == svc.Name && resNamespace == svc.Namespace {
-					s, err := DefaultObjectDescriber.DescribeObject(&svc)
-					if err != nil {
-						log.Fatalf("Error generating output for service %s/%s: %s", svc.Namespace, svc.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "Deployment") && (kind == "DeploymentList" || kind == "List") {
-			var deployList appsv1.DeploymentList
-			err := json.Unmarshal([]byte(buffer), &deployList)
-			if err != nil {
-				log.Fatalf("Error parsing deployment list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, deploy := range deployList.Items {
-				if resName == deploy.Name && resNamespace == deploy.Namespace {
-					s, err := DefaultObjectDescriber.DescribeObject(&deploy)
-					if err != nil {
-						log.Fatalf("Error generating output for deployment %s/%s: %s", deploy.Namespace, deploy.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "DaemonSet") && (kind == "DaemonSetList" || kind == "List") {
-			var daemonList appsv1.DaemonSetList
-			err := json.Unmarshal([]byte(buffer), &daemonList)
-			if err != nil {
-				log.Fatalf("Error parsing daemonset list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, daemon := range daemonList.Items {
-				if resName == daemon.Name && resNamespace == daemon.Namespace {
-					s, err := DefaultObjectDescriber.DescribeObject(&daemon)
-					if err != nil {
-						log.Fatalf("Error generating output for daemonset %s/%s: %s", daemon.Namespace, daemon.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "ReplicaSet") && (kind == "ReplicaSetList" || kind == "List") {
-			var replicaList appsv1.ReplicaSetList
-			err := json.Unmarshal([]byte(buffer), &replicaList)
-			if err != nil {
-				log.Fatalf("Error parsing replicaset list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, rs := range replicaList.Items {
-				if resName == rs.Name && resNamespace == rs.Namespace {
-					s, err := DefaultObjectDescriber.DescribeObject(&rs)
-					if err != nil {
-						log.Fatalf("Error generating output for replicaset %s/%s: %s", rs.Namespace, rs.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "StatefulSet") && (kind == "StatefulSetList" || kind == "List") {
-			var stsList appsv1.StatefulSetList
-			err := json.Unmarshal([]byte(buffer), &stsList)
-			if err != nil {
-				log.Fatalf("Error parsing statefulset list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, sts := range stsList.Items {
-				if resName == sts.Name && resNamespace == sts.Namespace {
-					s, err := DefaultObjectDescriber.DescribeObject(&sts)
-					if err != nil {
-						log.Fatalf("Error generating output for statefulset %s/%s: %s", sts.Namespace, sts.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "PersistentVolumeClaim") && (kind == "PersistentVolumeClaimList" || kind == "List") {
-			var pvcList corev1.PersistentVolumeClaimList
-			err := json.Unmarshal([]byte(buffer), &pvcList)
-			if err != nil {
-				log.Fatalf("Error parsing pvc list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, pvc := range pvcList.Items {
-				if resName == pvc.Name && resNamespace == pvc.Namespace {
-					s, err := DefaultObjectDescriber.DescribeObject(&pvc)
-					if err != nil {
-						log.Fatalf("Error generating output for pvc %s/%s: %s", pvc.Namespace, pvc.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "ConfigMap") && (kind == "ConfigMapList" || kind == "List") {
-			var cmList corev1.ConfigMapList
-			err := json.Unmarshal([]byte(buffer), &cmList)
-			if err != nil {
-				log.Fatalf("Error parsing cm list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, cm := range cmList.Items {
-				if resName == cm.Name && resNamespace == cm.Namespace {
-					s, err := describeConfigMap(&cm)
-					if err != nil {
-						log.Fatalf("Error generating output for cm %s/%s: %s", cm.Namespace, cm.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "Secret") && (kind == "SecretList" || kind == "List") {
-			var secretList corev1.SecretList
-			err := json.Unmarshal([]byte(buffer), &secretList)
-			if err != nil {
-				log.Fatalf("Error parsing secret list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, scrt := range secretList.Items {
-				if resName == scrt.Name && resNamespace == scrt.Namespace {
-					s, err := DefaultObjectDescriber.DescribeObject(&scrt)
-					if err != nil {
-						log.Fatalf("Error generating output for secret %s/%s: %s", scrt.Namespace, scrt.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "ServiceAccount") && (kind == "ServiceAccountList" || kind == "List") {
-			var saList corev1.ServiceAccountList
-			err := json.Unmarshal([]byte(buffer), &saList)
-			if err != nil {
-				log.Fatalf("Error parsing sa list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, sa := range saList.Items {
-				if resName == sa.Name && resNamespace == sa.Namespace {
-					s, err := DefaultObjectDescriber.DescribeObject(&sa)
-					if err != nil {
-						log.Fatalf("Error generating output for sa %s/%s: %s", sa.Namespace, sa.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "Ingress") && (kind == "IngressList" || kind == "List") {
-			var ingList networkingv1.IngressList
-			err := json.Unmarshal([]byte(buffer), &ingList)
-			if err != nil {
-				log.Fatalf("Error parsing ingress list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, ing := range ingList.Items {
-				if resName == ing.Name && resNamespace == ing.Namespace {
-					s, err := describeIngressV1(&ing)
-					if err != nil {
-						log.Fatalf("Error generating output for ingress %s/%s: %s", ing.Namespace, ing.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "Endpoints") && (kind == "EndpointsList" || kind == "List") {
-			var epList corev1.EndpointsList
-			err := json.Unmarshal([]byte(buffer), &epList)
-			if err != nil {
-				log.Fatalf("Error parsing ep list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, ep := range epList.Items {
-				if resName == ep.Name && resNamespace == ep.Namespace {
-					s, err := DefaultObjectDescriber.DescribeObject(&ep)
-					if err != nil {
-						log.Fatalf("Error generating output for ep %s/%s: %s", ep.Namespace, ep.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "Job") && (kind == "JobList" || kind == "List") {
-			var jobList batchv1.JobList
-			err := json.Unmarshal([]byte(buffer), &jobList)
-			if err != nil {
-				log.Fatalf("Error parsing job list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, job := range jobList.Items {
-				if resName == job.Name && resNamespace == job.Namespace {
-					s, err := DefaultObjectDescriber.DescribeObject(&job)
-					if err != nil {
-						log.Fatalf("Error generating output for job %s/%s: %s", job.Namespace, job.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "CronJob") && (kind == "CronJobList" || kind == "List") {
-			var jobList batchv1.CronJobList
-			err := json.Unmarshal([]byte(buffer), &jobList)
-			if err != nil {
-				log.Fatalf("Error parsing cron job list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, job := range jobList.Items {
-				if resName == job.Name && resNamespace == job.Namespace {
-					s, err := DefaultObjectDescriber.DescribeObject(&job)
-					if err != nil {
-						log.Fatalf("Error generating output for cron job %s/%s: %s", job.Namespace, job.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "Role") && (kind == "RoleList" || kind == "List") {
-			var roleList rbacv1.RoleList
-			err := json.Unmarshal([]byte(buffer), &roleList)
-			if err != nil {
-				log.Fatalf("Error parsing role list: %v\n%v\n", err.Error(), buffer)
-			}
-			for _, role := range roleList.Items {
-				if resName == role.Name {
-					s, err := describeRole(&role)
-					if err != nil {
-						log.Fatalf("Error generating output for role %s: %s", role.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
-				}
-			}
-		} else if inType(resType, "RoleBinding") && (kind == "RoleBindingList" || kind == "List") {
-			var rbList rbacv1.RoleBindingList
-			err := json.Unmarshal([]byte(buffer), &rbList)
-			if err != nil {
-				log.Fatalf("Error parsing role binding list: %v\n%v\n", err.Error(), buffer)
+	items := []interface{}{}
+	missing := []string{}
+	if len(names) == 0 {
+		for _, obj := range objects {
+			items = append(items, obj.Object)
+		}
+		// as kubectl, finding nothing to describe is not an error
+		if len(items) == 0 {
+			fmt.Fprintln(os.Stderr, noResourcesFound(namespace))
+		}
+		return items, missing
+	}
+	for _, name := range names {
+		found := []interface{}{}
+		for _, obj := range objects {
+			if obj.GetName() == name {
+				found = append(found, obj.Object)
 			}
-			for _, rb := range rbList.Items {
-				if resName == rb.Name {
-					s, err := describeRoleBinding(&rb)
-					if err != nil {
-						log.Fatalf("Error generating output for role binding %s: %s", rb.Name, err.Error())
-					}
-					fmt.Println(s)
-					break
+		}
+		if len(found) == 0 {
+			for _, obj := range objects {
+				if strings.HasPrefix(obj.GetName(), name) {
+					found = append(found, obj.Object)
 				}
 			}
 		}
-
-	}
-}
-
-func describeRoleBinding(binding *rbacv1.RoleBinding) (string, error) {
-	return tabbedString(func(out io.Writer) error {
-		w := NewPrefixWriter(out)
-		w.Write(LEVEL_0, "Name:\t%s\n", binding.Name)
-		printLabelsMultiline(w, "Labels", binding.Labels)
-		printAnnotationsMultiline(w, "Annotations", binding.Annotations)
-
-		w.Write(LEVEL_0, "Role:\n")
-		w.Write(LEVEL_1, "Kind:\t%s\n", binding.RoleRef.Kind)
-		w.Write(LEVEL_1, "Name:\t%s\n", binding.RoleRef.Name)
-
-		w.Write(LEVEL_0, "Subjects:\n")
-		w.Write(LEVEL_1, "Kind\tName\tNamespace\n")
-		w.Write(LEVEL_1, "----\t----\t---------\n")
-		for _, s := range binding.Subjects {
-			w.Write(LEVEL_1, "%s\t%s\t%s\n", s.Kind, s.Name, s.Namespace)
+		if len(found) == 0 {
+			missing = append(missing, fmt.Sprintf("%s %s is not found.", resType, name))
 		}
-
-		return nil
-	})
+		items = append(items, found...)
+	}
+	return items, missing
 }
 
-func describeClusterRoleBinding(binding *rbacv1.ClusterRoleBinding) (string, error) {
-	return tabbedString(func(out io.Writer) error {
-		w := NewPrefixWriter(out)
-		w.Write(LEVEL_0, "Name:\t%s\n", binding.Name)
-		printLabelsMultiline(w, "Labels", binding.Labels)
-		printAnnotationsMultiline(w, "Annotations", binding.Annotations)
-
-		w.Write(LEVEL_0, "Role:\n")
-		w.Write(LEVEL_1, "Kind:\t%s\n", binding.RoleRef.Kind)
-		w.Write(LEVEL_1, "Name:\t%s\n", binding.RoleRef.Name)
-
-		w.Write(LEVEL_0, "Subjects:\n")
-		w.Write(LEVEL_1, "Kind\tName\tNamespace\n")
-		w.Write(LEVEL_1, "----\t----\t---------\n")
-		for _, s := range binding.Subjects {
-			w.Write(LEVEL_1, "%s\t%s\t%s\n", s.Kind, s.Name, s.Namespace)
-		}
-
-		return nil
-	})
+// noResourcesFound returns the message kubectl gives when nothing matches.
+func noResourcesFound(namespace string) string {
+	if len(namespace) == 0 || !resource.Namespaced {
+		return "No resources found"
+	}
+	return fmt.Sprintf("No resources found in %s namespace.", namespace)
 }
 
-func describeRole(role *rbacv1.Role) (string, error) {
-	breakdownRules := []rbacv1.PolicyRule{}
-	for _, rule := range role.Rules {
-		breakdownRules = append(breakdownRules, rbac.BreakdownRule(rule)...)
-	}
+func tabbedString(f func(io.Writer) error) (string, error) {
+	out := new(tabwriter.Writer)
+	buf := &bytes.Buffer{}
+	out.Init(buf, 0, 8, 2, ' ', 0)
 
-	compactRules, err := rbac.CompactRules(breakdownRules)
+	err := f(out)
 	if err != nil {
 		return "", err
 	}
-	sort.Stable(rbac.SortableRuleSlice(compactRules))
-
-	return tabbedString(func(out io.Writer) error {
-		w := NewPrefixWriter(out)
-		w.Write(LEVEL_0, "Name:\t%s\n", role.Name)
-		printLabelsMultiline(w, "Labels", role.Labels)
-		printAnnotationsMultiline(w, "Annotations", role.Annotations)
-
-		w.Write(LEVEL_0, "PolicyRule:\n")
-		w.Write(LEVEL_1, "Resources\tNon-Resource URLs\tResource Names\tVerbs\n")
-		w.Write(LEVEL_1, "---------\t-----------------\t--------------\t-----\n")
-		for _, r := range compactRules {
-			w.Write(LEVEL_1, "%s\t%v\t%v\t%v\n", CombineResourceGroup(r.Resources, r.APIGroups), r.NonResourceURLs, r.ResourceNames, r.Verbs)
-		}
 
-		return nil
-	})
+	out.Flush()
+	return buf.String(), nil
 }
 
-func describeClusterRole(role *rbacv1.ClusterRole) (string, error) {
-	breakdownRules := []rbacv1.PolicyRule{}
-	for _, rule := range role.Rules {
-		breakdownRules = append(breakdownRules, rbac.BreakdownRule(rule)...)
+// describeObject prints the description of item, an object of resource.
+func describeObject(item interface{}) {
+	name := newObject(resource.Kind.Kind, item).str("metadata", "name")
+	obj, err := resource.typed(item)
+	if err != nil {
+		log.Fatalf("Error parsing %s %s: %v\n", resType, name, err.Error())
 	}
-
-	compactRules, err := rbac.CompactRules(breakdownRules)
+	describe := resource.describe
+	if describe == nil {
+		describe = describeGeneric
+	}
+	s, err := describe(obj)
 	if err != nil {
-		return "", err
+		log.Fatalf("Error generating output for %s %s: %s", resType, name, err.Error())
 	}
-	sort.Stable(rbac.SortableRuleSlice(compactRules))
-
-	return tabbedString(func(out io.Writer) error {
-		w := NewPrefixWriter(out)
-		w.Write(LEVEL_0, "Name:\t%s\n", role.Name)
-		printLabelsMultiline(w, "Labels", role.Labels)
-		printAnnotationsMultiline(w, "Annotations", role.Annotations)
-
-		w.Write(LEVEL_0, "PolicyRule:\n")
-		w.Write(LEVEL_1, "Resources\tNon-Resource URLs\tResource Names\tVerbs\n")
-		w.Write(LEVEL_1, "---------\t-----------------\t--------------\t-----\n")
-		for _, r := range compactRules {
-			w.Write(LEVEL_1, "%s\t%v\t%v\t%v\n", CombineResourceGroup(r.Resources, r.APIGroups), r.NonResourceURLs, r.ResourceNames, r.Verbs)
-		}
-
-		return nil
-	})
-}
-
-func describeConfigMap(configMap *corev1.ConfigMap) (string, error) {
-	return tabbedString(func(out io.Writer) error {
-		w := NewPrefixWriter(out)
-		w.Write(LEVEL_0, "Name:\t%s\n", configMap.Name)
-		w.Write(LEVEL_0, "Namespace:\t%s\n", configMap.Namespace)
-		printLabelsMultiline(w, "Labels", configMap.Labels)
-		printAnnotationsMultiline(w, "Annotations", configMap.Annotations)
-
-		w.Write(LEVEL_0, "\nData\n====\n")
-		for k, v := range configMap.Data {
-			w.Write(LEVEL_0, "%s:\n----\n", k)
-			w.Write(LEVEL_0, "%s\n", string(v))
-		}
-		w.Write(LEVEL_0, "\nBinaryData\n====\n")
-		for k, v := range configMap.BinaryData {
-			w.Write(LEVEL_0, "%s: %s bytes\n", k, strconv.Itoa(len(v)))
-		}
-		w.Write(LEVEL_0, "\n")
-
-		return nil
-	})
+	fmt.Println(s)
 }
 
-// printLabelsMultiline prints multiple labels with a proper alignment.
 func printLabelsMultiline(w PrefixWriter, title string, labels map[string]string) {
 	printLabelsMultilineWithIndent(w, "", title, "\t", labels, sets.NewString())
 }
@@ -685,133 +272,3 @@ func shorten(s string, maxLength int) string {
 	}
 	return s
 }
-
-func describeIngressV1(ing *networkingv1.Ingress) (string, error) {
-	return tabbedString(func(out io.Writer) error {
-		w := NewPrefixWriter(out)
-		w.Write(LEVEL_0, "Name:\t%v\n", ing.Name)
-		printLabelsMultiline(w, "Labels", ing.Labels)
-		w.Write(LEVEL_0, "Namespace:\t%v\n", ing.Namespace)
-		w.Write(LEVEL_0, "Address:\t%v\n", ingressLoadBalancerStatusStringerV1(ing.Status.LoadBalancer, true))
-		ingressClassName := "<none>"
-		if ing.Spec.IngressClassName != nil {
-			ingressClassName = *ing.Spec.IngressClassName
-		}
-		w.Write(LEVEL_0, "Ingress Class:\t%v\n", ingressClassName)
-		def := ing.Spec.DefaultBackend
-		ns := ing.Namespace
-		defaultBackendDescribe := "<default>"
-		if def != nil {
-			defaultBackendDescribe = describeBackendV1(ns, def)
-		}
-		w.Write(LEVEL_0, "Default backend:\t%s\n", defaultBackendDescribe)
-		if len(ing.Spec.TLS) != 0 {
-			describeIngressTLSV1(w, ing.Spec.TLS)
-		}
-		w.Write(LEVEL_0, "Rules:\n  Host\tPath\tBackends\n")
-		w.Write(LEVEL_1, "----\t----\t--------\n")
-		count := 0
-		for _, rules := range ing.Spec.Rules {
-
-			if rules.HTTP == nil {
-				continue
-			}
-			count++
-			host := rules.Host
-			if len(host) == 0 {
-				host = "*"
-			}
-			w.Write(LEVEL_1, "%s\t\n", host)
-			for _, path := range rules.HTTP.Paths {
-				w.Write(LEVEL_2, "\t%s \t%s\n", path.Path, describeBackendV1(ing.Namespace, &path.Backend))
-			}
-		}
-		if count == 0 {
-			w.Write(LEVEL_1, "%s\t%s\t%s\n", "*", "*", defaultBackendDescribe)
-		}
-		printAnnotationsMultiline(w, "Annotations", ing.Annotations)
-
-		return nil
-	})
-}
-
-func describeBackendV1(ns string, backend *networkingv1.IngressBackend) string {
-
-	if backend.Service != nil {
-		sb := serviceBackendStringer(backend.Service)
-		// endpoints, err := i.client.CoreV1().Endpoints(ns).Get(context.TODO(), backend.Service.Name, metav1.GetOptions{})
-		// if err != nil {
-		// 	return fmt.Sprintf("%v (<error: %v>)", sb, err)
-		// }
-		// service, err := i.client.CoreV1().Services(ns).Get(context.TODO(), backend.Service.Name, metav1.GetOptions{})
-		// if err != nil {
-		// 	return fmt.Sprintf("%v(<error: %v>)", sb, err)
-		// }
-		// spName := ""
-		// for i := range service.Spec.Ports {
-		// 	sp := &service.Spec.Ports[i]
-		// 	if backend.Service.Port.Number != 0 && backend.Service.Port.Number == sp.Port {
-		// 		spName = sp.Name
-		// 	} else if len(backend.Service.Port.Name) > 0 && backend.Service.Port.Name == sp.Name {
-		// 		spName = sp.Name
-		// 	}
-		// }
-		// ep := formatEndpoints(endpoints, sets.NewString(spName))
-		return fmt.Sprintf("%s", sb)
-	}
-	if backend.Resource != nil {
-		ic := backend.Resource
-		apiGroup := "<none>"
-		if ic.APIGroup != nil {
-			apiGroup = fmt.Sprintf("%v", *ic.APIGroup)
-		}
-		return fmt.Sprintf("APIGroup: %v, Kind: %v, Name: %v", apiGroup, ic.Kind, ic.Name)
-	}
-	return ""
-}
-
-// backendStringer behaves just like a string interface and converts the given backend to a string.
-func serviceBackendStringer(backend *networkingv1.IngressServiceBackend) string {
-	if backend == nil {
-		return ""
-	}
-	var bPort string
-	if backend.Port.Number != 0 {
-		sNum := int64(backend.Port.Number)
-		bPort = strconv.FormatInt(sNum, 10)
-	} else {
-		bPort = backend.Port.Name
-	}
-	return fmt.Sprintf("%v:%v", backend.Name, bPort)
-}
-
-// ingressLoadBalancerStatusStringerV1 behaves mostly like a string interface and converts the given status to a string.
-// `wide` indicates whether the returned value is meant for --o=wide output. If not, it's clipped to 16 bytes.
-func ingressLoadBalancerStatusStringerV1(s networkingv1.IngressLoadBalancerStatus, wide bool) string {
-	ingress := s.Ingress
-	result := sets.NewString()
-	for i := range ingress {
-		if ingress[i].IP != "" {
-			result.Insert(ingress[i].IP)
-		} else if ingress[i].Hostname != "" {
-			result.Insert(ingress[i].Hostname)
-		}
-	}
-
-	r := strings.Join(result.List(), ",")
-	if !wide && len(r) > LoadBalancerWidth {
-		r = r[0:(LoadBalancerWidth-3)] + "..."
-	}
-	return r
-}
-
-func describeIngressTLSV1(w PrefixWriter, ingTLS []networkingv1.IngressTLS) {
-	w.Write(LEVEL_0, "TLS:\n")
-	for _, t := range ingTLS {
-		if t.SecretName == "" {
-			w.Write(LEVEL_1, "SNI routes %v\n", strings.Join(t.Hosts, ","))
-		} else {
-			w.Write(LEVEL_1, "%v terminates %v\n", t.SecretName, strings.Join(t.Hosts, ","))
-		}
-	}
-}
@@ -3,12 +3,22 @@ package cli
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/dynamic"
+	admissionclient "k8s.io/client-go/kubernetes/typed/admissionregistration/v1"
+	autoscalingclient "k8s.io/client-go/kubernetes/typed/autoscaling/v2"
 	batchclient "k8s.io/client-go/kubernetes/typed/batch/v1"
+	discoveryclient "k8s.io/client-go/kubernetes/typed/discovery/v1"
 	networkingclient "k8s.io/client-go/kubernetes/typed/networking/v1"
+	policyclient "k8s.io/client-go/kubernetes/typed/policy/v1"
 	rbacclient "k8s.io/client-go/kubernetes/typed/rbac/v1"
+	schedulingclient "k8s.io/client-go/kubernetes/typed/scheduling/v1"
 	storageclient "k8s.io/client-go/kubernetes/typed/storage/v1"
 	. "k8s.io/kubectl/pkg/cmd/clusterinfo"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
@@ -16,11 +26,14 @@ import (
 
 	// "k8s.io/kubectl/pkg/cmd/plugin"
 	// "k8s.io/kubectl/pkg/cmd"
+	"fmt"
 	"io"
 	"os"
 	"path"
 	"time"
 
+	"github.com/shundezhang/kubedmp/cmd/build"
+	"github.com/shundezhang/kubedmp/pkg/dump"
 	"github.com/spf13/cobra"
 )
 
@@ -30,10 +43,18 @@ const (
 )
 
 type ExtraInfoDumpOptions struct {
-	NetworkingClient networkingclient.NetworkingV1Interface
-	StorageClient    storageclient.StorageV1Interface
-	BatchClient      batchclient.BatchV1Interface
-	RbacClient       *rbacclient.RbacV1Client
+	NetworkingClient  networkingclient.NetworkingV1Interface
+	StorageClient     storageclient.StorageV1Interface
+	BatchClient       batchclient.BatchV1Interface
+	RbacClient        *rbacclient.RbacV1Client
+	AutoscalingClient autoscalingclient.AutoscalingV2Interface
+	PolicyClient      policyclient.PolicyV1Interface
+	SchedulingClient  schedulingclient.SchedulingV1Interface
+	DiscoveryClient   discoveryclient.DiscoveryV1Interface
+	AdmissionClient   admissionclient.AdmissionregistrationV1Interface
+	// DynamicClient lists the kinds client-go has no typed client for, such
+	// as the APIServices of apiregistration.k8s.io.
+	DynamicClient dynamic.Interface
 	ClusterInfoDumpOptions
 }
 
@@ -94,7 +115,7 @@ kubedmp dump --namespaces default,kube-system --output-directory=/path/to/cluste
 			cmdutil.CheckErr(o.Run())
 		},
 	}
-	// o.PrintFlags.AddFlags(dumpCmd)
+	o.PrintFlags.AddFlags(dumpCmd)
 	dumpCmd.Flags().StringVar(&o.OutputDir, "output-directory", o.OutputDir, "Where to output the files.  If empty or '-' uses stdout, otherwise creates a directory hierarchy in that directory")
 	dumpCmd.Flags().StringSliceVar(&o.Namespaces, "namespaces", o.Namespaces, "A comma separated list of namespaces to dump.")
 	dumpCmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If true, dump all namespaces.  If true, --namespaces is ignored.")
@@ -142,6 +163,36 @@ func (o *ExtraInfoDumpOptions) CompleteExtra(restClientGetter genericclioptions.
 		return err
 	}
 
+	o.AutoscalingClient, err = autoscalingclient.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	o.PolicyClient, err = policyclient.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	o.SchedulingClient, err = schedulingclient.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	o.DiscoveryClient, err = discoveryclient.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	o.AdmissionClient, err = admissionclient.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	o.DynamicClient, err = dynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -157,43 +208,79 @@ func (o *ExtraInfoDumpOptions) runExtra() error {
 		}
 	}
 
-	pvs, err := o.CoreClient.PersistentVolumes().List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-	if err := o.PrintObj(pvs, setupOutputWriter(o.OutputDir, o.Out, DumpFileNames["PersistentVolume"], fileExtension)); err != nil {
+	// The manifest goes first so that readers find the capture time without
+	// scanning the whole dump.
+	manifest := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion":  dump.ManifestAPIVersion,
+		"kind":        dump.ManifestKind,
+		"captureTime": time.Now().UTC().Format(time.RFC3339),
+		"version":     build.Version,
+	}}
+	if err := o.PrintObj(manifest, setupOutputWriter(o.OutputDir, o.Out, dump.ManifestFile, fileExtension)); err != nil {
 		return err
 	}
 
-	scs, err := o.StorageClient.StorageClasses().List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-	if err := o.PrintObj(scs, setupOutputWriter(o.OutputDir, o.Out, DumpFileNames["StorageClass"], fileExtension)); err != nil {
-		return err
+	for _, t := range resourceTypes {
+		if t.list == nil || t.Namespaced {
+			continue
+		}
+		list, err := o.listType(t, "")
+		if err != nil {
+			return err
+		}
+		if list == nil {
+			continue
+		}
+		if err := o.PrintObj(list, setupOutputWriter(o.OutputDir, o.Out, t.FileName, fileExtension)); err != nil {
+			return err
+		}
 	}
 
-	clusterroles, err := o.RbacClient.ClusterRoles().List(context.TODO(), metav1.ListOptions{})
+	namespaces, err := o.namespaces()
 	if err != nil {
 		return err
 	}
-	if err := o.PrintObj(clusterroles, setupOutputWriter(o.OutputDir, o.Out, DumpFileNames["ClusterRole"], fileExtension)); err != nil {
-		return err
+	for _, namespace := range namespaces {
+		for _, t := range resourceTypes {
+			if t.list == nil || !t.Namespaced {
+				continue
+			}
+			list, err := o.listType(t, namespace)
+			if err != nil {
+				return err
+			}
+			if list == nil {
+				continue
+			}
+			if err := o.PrintObj(list, setupOutputWriter(o.OutputDir, o.Out, path.Join(namespace, t.FileName), fileExtension)); err != nil {
+				return err
+			}
+		}
 	}
+	return nil
+}
 
-	clusterrolebindings, err := o.RbacClient.ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-	if err := o.PrintObj(clusterrolebindings, setupOutputWriter(o.OutputDir, o.Out, DumpFileNames["ClusterRoleBinding"], fileExtension)); err != nil {
-		return err
+// listType lists the objects of t in namespace. Kinds the cluster does not
+// serve, such as autoscaling/v2 before Kubernetes 1.23, or that the user may
+// not list are skipped with a warning instead of failing the dump.
+func (o *ExtraInfoDumpOptions) listType(t *resourceType, namespace string) (runtime.Object, error) {
+	list, err := t.list(o, namespace)
+	if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+		fmt.Fprintf(o.ErrOut, "Warning: skipped %s: %v\n", t.Resource, err)
+		return nil, nil
 	}
+	return list, err
+}
 
+// namespaces returns the names of the namespaces to dump: all of them with
+// --all-namespaces, otherwise those given with --namespaces, or kube-system
+// and the current namespace.
+func (o *ExtraInfoDumpOptions) namespaces() ([]string, error) {
 	var namespaces []string
 	if o.AllNamespaces {
 		namespaceList, err := o.CoreClient.Namespaces().List(context.TODO(), metav1.ListOptions{})
 		if err != nil {
-			return err
+			return nil, err
 		}
 		for ix := range namespaceList.Items {
 			namespaces = append(namespaces, namespaceList.Items[ix].Name)
@@ -208,95 +295,44 @@ func (o *ExtraInfoDumpOptions) runExtra() error {
 			namespaces = o.Namespaces
 		}
 	}
-	for _, namespace := range namespaces {
-		endpoints, err := o.CoreClient.Endpoints(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		if err := o.PrintObj(endpoints, setupOutputWriter(o.OutputDir, o.Out, path.Join(namespace, DumpFileNames["Endpoints"]), fileExtension)); err != nil {
-			return err
-		}
-
-		pvcs, err := o.CoreClient.PersistentVolumeClaims(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		if err := o.PrintObj(pvcs, setupOutputWriter(o.OutputDir, o.Out, path.Join(namespace, DumpFileNames["PersistentVolumeClaim"]), fileExtension)); err != nil {
-			return err
-		}
-
-		secrets, err := o.CoreClient.Secrets(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		if err := o.PrintObj(secrets, setupOutputWriter(o.OutputDir, o.Out, path.Join(namespace, DumpFileNames["Secret"]), fileExtension)); err != nil {
-			return err
-		}
-
-		configmaps, err := o.CoreClient.ConfigMaps(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		if err := o.PrintObj(configmaps, setupOutputWriter(o.OutputDir, o.Out, path.Join(namespace, DumpFileNames["ConfigMap"]), fileExtension)); err != nil {
-			return err
-		}
-
-		serviceaccounts, err := o.CoreClient.ServiceAccounts(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		if err := o.PrintObj(serviceaccounts, setupOutputWriter(o.OutputDir, o.Out, path.Join(namespace, DumpFileNames["ServiceAccount"]), fileExtension)); err != nil {
-			return err
-		}
-
-		statefulsets, err := o.AppsClient.StatefulSets(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		if err := o.PrintObj(statefulsets, setupOutputWriter(o.OutputDir, o.Out, path.Join(namespace, DumpFileNames["StatefulSet"]), fileExtension)); err != nil {
-			return err
-		}
-
-		ingresses, err := o.NetworkingClient.Ingresses(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		if err := o.PrintObj(ingresses, setupOutputWriter(o.OutputDir, o.Out, path.Join(namespace, DumpFileNames["Ingress"]), fileExtension)); err != nil {
-			return err
-		}
-
-		jobs, err := o.BatchClient.Jobs(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		if err := o.PrintObj(jobs, setupOutputWriter(o.OutputDir, o.Out, path.Join(namespace, DumpFileNames["Job"]), fileExtension)); err != nil {
-			return err
-		}
-
-		cronjobs, err := o.BatchClient.CronJobs(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		if err := o.PrintObj(cronjobs, setupOutputWriter(o.OutputDir, o.Out, path.Join(namespace, DumpFileNames["CronJob"]), fileExtension)); err != nil {
-			return err
-		}
+	return namespaces, nil
+}
 
-		roles, err := o.RbacClient.Roles(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		if err := o.PrintObj(roles, setupOutputWriter(o.OutputDir, o.Out, path.Join(namespace, DumpFileNames["Role"]), fileExtension)); err != nil {
-			return err
+// namespaceList returns the Namespace objects of the namespaces to dump.
+func (o *ExtraInfoDumpOptions) namespaceList() (*corev1.NamespaceList, error) {
+	if o.AllNamespaces {
+		return o.CoreClient.Namespaces().List(context.TODO(), metav1.ListOptions{})
+	}
+	namespaces, err := o.namespaces()
+	if err != nil {
+		return nil, err
+	}
+	namespaceList := &corev1.NamespaceList{}
+	for _, namespace := range namespaces {
+		ns, err := o.CoreClient.Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
 		}
-
-		rolebindings, err := o.RbacClient.RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{})
 		if err != nil {
-			return err
-		}
-		if err := o.PrintObj(rolebindings, setupOutputWriter(o.OutputDir, o.Out, path.Join(namespace, DumpFileNames["RoleBinding"]), fileExtension)); err != nil {
-			return err
+			return nil, err
 		}
+		namespaceList.Items = append(namespaceList.Items, *ns)
+	}
+	return namespaceList, nil
+}
 
+// listDynamic lists the objects of k in namespace, or in the cluster if
+// namespace is empty, with the dynamic client. It returns nil if the cluster
+// does not serve k, such as VolumeSnapshots without the CRDs of the snapshot
+// controller.
+func (o *ExtraInfoDumpOptions) listDynamic(k dump.Kind, namespace string) (runtime.Object, error) {
+	gvr := k.GroupVersionKind().GroupVersion().WithResource(k.Resource)
+	list, err := o.DynamicClient.Resource(gvr).Namespace(namespace).List(context.TODO(), metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
 	}
-	return nil
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
 }
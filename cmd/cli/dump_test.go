@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestListType(t *testing.T) {
+	hpas := schema.GroupResource{Group: "autoscaling", Resource: "horizontalpodautoscalers"}
+	tests := []struct {
+		name    string
+		err     error
+		skipped bool
+	}{
+		{name: "listed"},
+		{name: "not served", err: apierrors.NewNotFound(hpas, ""), skipped: true},
+		{name: "forbidden", err: apierrors.NewForbidden(hpas, "", errors.New("no access")), skipped: true},
+		{name: "failed", err: apierrors.NewInternalError(errors.New("etcd is down"))},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errOut := &bytes.Buffer{}
+			o := &ExtraInfoDumpOptions{}
+			o.ErrOut = errOut
+			rt := &resourceType{
+				Kind: kind("HorizontalPodAutoscaler"),
+				list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+					return &corev1.PodList{}, test.err
+				},
+			}
+			list, err := o.listType(rt, "default")
+			switch {
+			case test.skipped:
+				if list != nil || err != nil || errOut.Len() == 0 {
+					t.Errorf("listType = %v, %v with warning %q, want the kind skipped with a warning", list, err, errOut.String())
+				}
+			case test.err != nil:
+				if err != test.err {
+					t.Errorf("listType error = %v, want %v", err, test.err)
+				}
+			default:
+				if list == nil || err != nil {
+					t.Errorf("listType = %v, %v, want the list", list, err)
+				}
+			}
+		})
+	}
+}
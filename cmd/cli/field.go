@@ -0,0 +1,308 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shundezhang/kubedmp/pkg/dump"
+)
+
+const none = "<none>"
+
+var (
+	strict bool
+	// malformed lists the problems found in the objects printed so far, one
+	// line per field. Only --strict collects and reports them.
+	malformed        []string
+	malformedSeen    = map[string]bool{}
+	malformedObjects = map[*object]bool{}
+)
+
+// object gives the printers tolerant access to the fields of an item read
+// from the dump. A missing field reads as empty, or as <none> through text,
+// instead of panicking; a field of the wrong type reads the same way and is
+// recorded as malformed.
+type object struct {
+	// item is the object printed, for objects nested in it such as the
+	// containers of a pod.
+	item *object
+	// path is where the object is in item, e.g. ".spec.containers[0]".
+	path string
+	kind string
+	data map[string]interface{}
+}
+
+// newObject wraps item, an object of kind. An object without a name or a
+// creation time is recorded as malformed, unless the dump made it up, see
+// record.
+func newObject(kind string, item interface{}) *object {
+	o := &object{kind: kind}
+	o.item = o
+	data, ok := item.(map[string]interface{})
+	if !ok {
+		o.problem(nil, "is %s, not an object", typeName(item))
+		return o
+	}
+	o.data = data
+	if len(o.str("metadata", "name")) == 0 {
+		o.problem([]string{"metadata", "name"}, "is missing")
+	}
+	if len(o.str("metadata", "creationTimestamp")) == 0 {
+		o.problem([]string{"metadata", "creationTimestamp"}, "is missing")
+	}
+	return o
+}
+
+func (o *object) field(fields ...string) interface{} {
+	var value interface{} = o.data
+	for i, field := range fields {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			if value != nil {
+				o.problem(fields[:i], "is %s, not an object", typeName(value))
+			}
+			return nil
+		}
+		value = m[field]
+	}
+	return value
+}
+
+func (o *object) has(fields ...string) bool {
+	return o.field(fields...) != nil
+}
+
+// str returns a string field, or "" if it is missing.
+func (o *object) str(fields ...string) string {
+	switch value := o.field(fields...).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		o.problem(fields, "is %s, not a string", typeName(value))
+		return ""
+	}
+}
+
+// text returns a field for display, or <none> if it is missing or empty.
+// Numbers and booleans are formatted.
+func (o *object) text(fields ...string) string {
+	switch value := o.field(fields...).(type) {
+	case nil:
+		return none
+	case string:
+		if len(value) == 0 {
+			return none
+		}
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		o.problem(fields, "is %s, not a value", typeName(value))
+		return none
+	}
+}
+
+// name returns the name of o for display. When get prints several types, the
+// name is prefixed with the type, as in pod/web-1 or deployment.apps/web.
+func (o *object) name() string {
+	name := o.text("metadata", "name")
+	if !showKind {
+		return name
+	}
+	prefix := strings.ToLower(o.kind)
+	if len(resource.Group) > 0 {
+		prefix += "." + resource.Group
+	}
+	return prefix + "/" + name
+}
+
+// num returns a number field, or 0 if it is missing.
+func (o *object) num(fields ...string) int64 {
+	switch value := o.field(fields...).(type) {
+	case nil:
+		return 0
+	case float64:
+		return int64(value)
+	default:
+		o.problem(fields, "is %s, not a number", typeName(value))
+		return 0
+	}
+}
+
+// count returns a number field formatted, or <none> if it is missing.
+func (o *object) count(fields ...string) string {
+	if !o.has(fields...) {
+		return none
+	}
+	return strconv.FormatInt(o.num(fields...), 10)
+}
+
+func (o *object) flag(fields ...string) bool {
+	switch value := o.field(fields...).(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	default:
+		o.problem(fields, "is %s, not a boolean", typeName(value))
+		return false
+	}
+}
+
+func (o *object) list(fields ...string) []interface{} {
+	switch value := o.field(fields...).(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return value
+	default:
+		o.problem(fields, "is %s, not a list", typeName(value))
+		return nil
+	}
+}
+
+func (o *object) dict(fields ...string) map[string]interface{} {
+	switch value := o.field(fields...).(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		return value
+	default:
+		o.problem(fields, "is %s, not an object", typeName(value))
+		return nil
+	}
+}
+
+// child returns the object held by a field, which is empty if it is missing.
+func (o *object) child(fields ...string) *object {
+	return &object{item: o.item, path: o.path + fieldPath(fields), kind: o.kind, data: o.dict(fields...)}
+}
+
+// objects returns the elements of a list of objects. Elements that are not
+// objects are recorded as malformed and skipped.
+func (o *object) objects(fields ...string) []*object {
+	objects := []*object{}
+	for i, value := range o.list(fields...) {
+		path := o.path + fieldPath(fields) + "[" + strconv.Itoa(i) + "]"
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			o.item.record(path, "is %s, not an object", typeName(value))
+			continue
+		}
+		objects = append(objects, &object{item: o.item, path: path, kind: o.kind, data: data})
+	}
+	return objects
+}
+
+// strs returns the elements of a list of strings. Elements that are not
+// strings are recorded as malformed and skipped.
+func (o *object) strs(fields ...string) []string {
+	strs := []string{}
+	for i, value := range o.list(fields...) {
+		s, ok := value.(string)
+		if !ok {
+			o.item.record(o.path+fieldPath(fields)+"["+strconv.Itoa(i)+"]", "is %s, not a string", typeName(value))
+			continue
+		}
+		strs = append(strs, s)
+	}
+	return strs
+}
+
+// pairs returns a map of strings, such as labels or a node selector, as
+// sorted key=value pairs.
+func (o *object) pairs(fields ...string) []string {
+	pairs := []string{}
+	for k, v := range o.dict(fields...) {
+		s, ok := v.(string)
+		if !ok {
+			o.item.record(o.path+fieldPath(append(fields, k)), "is %s, not a string", typeName(v))
+			continue
+		}
+		pairs = append(pairs, k+"="+s)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+func (o *object) problem(fields []string, format string, args ...interface{}) {
+	o.item.record(o.path+fieldPath(fields), format, args...)
+}
+
+// record adds a problem with the field at path of the item to malformed.
+// Objects the dump made up, such as the Namespaces of a dump without them,
+// lack the fields only a cluster sets and are not checked.
+func (o *object) record(path string, format string, args ...interface{}) {
+	if !strict || dump.Synthesized(o.data) {
+		return
+	}
+	if len(path) == 0 {
+		path = "."
+	}
+	metadata, _ := o.data["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	name = orNone(name)
+	if namespace, _ := metadata["namespace"].(string); len(namespace) > 0 {
+		name = namespace + "/" + name
+	}
+	line := fmt.Sprintf("%s %s: %s %s", o.kind, name, path, fmt.Sprintf(format, args...))
+	if source := dump.Source(o.data); len(source) > 0 {
+		line = source + ": " + line
+	}
+	if !malformedSeen[line] {
+		malformedSeen[line] = true
+		malformedObjects[o] = true
+		malformed = append(malformed, line)
+	}
+}
+
+func fieldPath(fields []string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	return "." + strings.Join(fields, ".")
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// orNone returns s, or <none> if it is empty.
+func orNone(s string) string {
+	if len(s) == 0 {
+		return none
+	}
+	return s
+}
+
+// reportMalformed lists the malformed objects found with --strict and exits
+// with an error if there were any.
+func reportMalformed() {
+	if len(malformed) == 0 {
+		return
+	}
+	for _, line := range malformed {
+		fmt.Fprintln(os.Stderr, line)
+	}
+	log.Fatalf("Found %d malformed objects in the dump.", len(malformedObjects))
+}
@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/fatih/camelcase"
+	"github.com/shundezhang/kubedmp/pkg/dump"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+	. "k8s.io/kubectl/pkg/describe"
+)
+
+// ageColumn is the column of kinds without printer columns of their own.
+var ageColumn = dump.PrinterColumn{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"}
+
+// printGenericList prints objects of a kind without a printer of its own,
+// such as custom resources, with the additionalPrinterColumns of its
+// CustomResourceDefinition, or only their age.
+func printGenericList(t *resourceType, items []interface{}) {
+	columns := []dump.PrinterColumn{}
+	for _, column := range t.PrinterColumns {
+		if column.Priority == 0 || wide {
+			columns = append(columns, column)
+		}
+	}
+	if len(t.PrinterColumns) == 0 {
+		columns = append(columns, ageColumn)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	if t.Namespaced {
+		fmt.Fprint(writer, "NAMESPACE\t")
+	}
+	fmt.Fprint(writer, "NAME")
+	for _, column := range columns {
+		fmt.Fprint(writer, "\t"+strings.ToUpper(column.Name))
+	}
+	fmt.Fprintln(writer, labelHeaders())
+	for _, item := range items {
+		obj := newObject(t.Kind.Kind, item)
+		fmt.Fprint(writer, sourceColumn(item))
+		if t.Namespaced {
+			fmt.Fprint(writer, obj.text("metadata", "namespace")+"\t")
+		}
+		fmt.Fprint(writer, obj.name())
+		for _, column := range columns {
+			fmt.Fprint(writer, "\t"+columnValue(obj, column))
+		}
+		fmt.Fprintln(writer, labelValues(item))
+	}
+	writer.Flush()
+}
+
+// columnValue returns the value of a printer column of obj, or <none> if the
+// field is missing. Dates are shown as ages.
+func columnValue(obj *object, column dump.PrinterColumn) string {
+	path := jsonpath.New(column.Name).AllowMissingKeys(true)
+	if err := path.Parse("{" + column.JSONPath + "}"); err != nil {
+		return none
+	}
+	results, err := path.FindResults(obj.data)
+	if err != nil || len(results) == 0 {
+		return none
+	}
+	values := []string{}
+	for _, result := range results[0] {
+		if !result.IsValid() || !result.CanInterface() || result.Interface() == nil {
+			continue
+		}
+		switch value := result.Interface().(type) {
+		case string:
+			if column.Type == "date" {
+				value = getAge(value)
+			}
+			values = append(values, value)
+		case float64:
+			values = append(values, strconv.FormatFloat(value, 'f', -1, 64))
+		case int64:
+			values = append(values, strconv.FormatInt(value, 10))
+		case bool:
+			values = append(values, strconv.FormatBool(value))
+		default:
+			data, err := json.Marshal(value)
+			if err == nil {
+				values = append(values, string(data))
+			}
+		}
+	}
+	if len(values) == 0 {
+		return none
+	}
+	return strings.Join(values, ",")
+}
+
+// describeGeneric describes an object of a kind without a describer of its
+// own. Kinds client-go knows go to the describer of kubectl if it has one;
+// the others are printed field by field as kubectl describe does for custom
+// resources.
+func describeGeneric(obj runtime.Object) (string, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return describeDefault(obj)
+	}
+	return describeFields(u)
+}
+
+// describeFields prints u field by field.
+func describeFields(u *unstructured.Unstructured) (string, error) {
+	return tabbedString(func(out io.Writer) error {
+		w := NewPrefixWriter(out)
+		w.Write(LEVEL_0, "Name:\t%s\n", u.GetName())
+		w.Write(LEVEL_0, "Namespace:\t%s\n", u.GetNamespace())
+		printLabelsMultiline(w, "Labels", u.GetLabels())
+		printAnnotationsMultiline(w, "Annotations", u.GetAnnotations())
+		printUnstructuredContent(w, LEVEL_0, u.UnstructuredContent(), "", ".metadata.managedFields", ".metadata.name",
+			".metadata.namespace", ".metadata.labels", ".metadata.annotations", "."+dump.SourceKey, "."+dump.SynthesizedKey)
+		return nil
+	})
+}
+
+func printUnstructuredContent(w PrefixWriter, level int, content map[string]interface{}, skipPrefix string, skip ...string) {
+	fields := []string{}
+	for field := range content {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		skipExpr := skipPrefix + "." + field
+		if contains(skip, skipExpr) {
+			continue
+		}
+		switch value := content[field].(type) {
+		case map[string]interface{}:
+			w.Write(level, "%s:\n", smartLabelFor(field))
+			printUnstructuredContent(w, level+1, value, skipExpr, skip...)
+		case []interface{}:
+			w.Write(level, "%s:\n", smartLabelFor(field))
+			for _, child := range value {
+				switch child := child.(type) {
+				case map[string]interface{}:
+					printUnstructuredContent(w, level+1, child, skipExpr, skip...)
+				default:
+					w.Write(level+1, "%v\n", child)
+				}
+			}
+		default:
+			w.Write(level, "%s:\t%v\n", smartLabelFor(field), value)
+		}
+	}
+}
+
+// smartLabelFor turns a field name such as "apiVersion" into a label such as
+// "API Version".
+func smartLabelFor(field string) string {
+	// skip creating smart label if field name contains
+	// special characters other than '-'
+	if strings.IndexFunc(field, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-'
+	}) != -1 {
+		return field
+	}
+
+	commonAcronyms := []string{"API", "URL", "UID", "OSB", "GUID"}
+	parts := camelcase.Split(field)
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part == "_" {
+			continue
+		}
+		if contains(commonAcronyms, strings.ToUpper(part)) {
+			part = strings.ToUpper(part)
+		} else {
+			part = strings.ToUpper(part[:1]) + part[1:]
+		}
+		result = append(result, part)
+	}
+	return strings.Join(result, " ")
+}
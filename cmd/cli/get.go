@@ -2,32 +2,58 @@ package cli
 
 import (
 	// "bufio"
-	"encoding/json"
 
-	// "fmt"
+	"fmt"
 	"log"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
 var (
-	displayItems []interface{}
+	displayItems  []interface{}
+	getPrintFlags *genericclioptions.PrintFlags
+	// showKind is set when get prints several types.
+	showKind bool
 )
 
+// allTypes are the types get all lists.
+var allTypes = []string{"pods", "services", "deployments", "replicasets", "statefulsets", "daemonsets", "jobs", "cronjobs"}
+
 var getCmd = &cobra.Command{
-	Use:                   "get TYPE [-n NAMESPACE | -A]",
+	Use:                   "get (TYPE[,TYPE...] [NAME...] | TYPE/NAME...) [-n NAMESPACE | -A]",
 	DisableFlagsInUseLine: true,
 	Short:                 "Display one or many resources",
-	Long: `Display one or many resources of a type. 
-Prints a table of the most important information about resources of the specific type.`,
+	Long: `Display one or many resources of one or more types.
+Prints a table of the most important information about resources of each type.`,
 	Example: `  # Lists all pods in kube-system namespace in ps output format, the output contains all fields in 'kubectl get -o wide'
   kubedmp get po -n kube-system
   
   # List all nodes
-  kubedmp get no`,
+  kubedmp get no
+
+  # List the pods, services and deployments of all namespaces
+  kubedmp get po,svc,deploy -A
+
+  # List the workloads of the default namespace
+  kubedmp get all
+
+  # List a deployment and a pod
+  kubedmp get deploy/web pod/web-6d4b9c7f5-x2x4k
+
+  # List the pods that restarted most last
+  kubedmp get po -A --sort-by=.status.containerStatuses[0].restartCount
+
+  # List the failed pods of app web
+  kubedmp get po -A -l app=web --field-selector status.phase=Failed
+
+  # Print a pod in YAML
+  kubedmp get po coredns-6bcf44f4cc-j9wkq -n kube-system -o yaml
+
+  # List the image of every pod
+  kubedmp get po -A -o custom-columns=NAME:.metadata.name,IMAGE:.spec.containers[*].image`,
 	// Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 
@@ -37,27 +63,31 @@ Prints a table of the most important information about resources of the specific
 		}
 
 		resType = args[0]
+		openDump()
+		requests := parseGetArgs(args)
+		showKind = len(requests) > 1
 		resName = ""
-		if len(args) > 1 {
-			resName = args[1]
+		if len(requests) == 1 && len(requests[0].names) == 1 {
+			resName = requests[0].names[0]
 		}
-		var err error
-		resKind, err = getKind(resType)
-		if err != nil {
-			log.Fatalf("%s is not a supported resource type.\n", resType)
-			return
+		// fmt.Printf("In get: parsing dump file %s\n", dumpFile)
+		missing := getItems(requests)
+		displayItems = []interface{}{}
+		for _, r := range requests {
+			sortItems(r.items)
+			displayItems = append(displayItems, r.items...)
 		}
-		if contains(UnnamespacedTypes, resKind) {
-			resNamespace = ""
+		if !printObjects(getPrintFlags, displayItems) {
+			resolveCaptureTime()
+			printItems(requests)
+			reportMalformed()
 		}
-		// fmt.Printf("In get: parsing dump file %s\n", dumpFile)
-		displayItems = make([]interface{}, 0)
-		if len(dumpDir) > 0 {
-			traverseDir()
-		} else {
-			readFile(dumpFile, processDoc)
+		for _, m := range missing {
+			log.Println(m)
+		}
+		if len(missing) > 0 {
+			os.Exit(1)
 		}
-		printItems()
 	},
 }
 
@@ -65,192 +95,136 @@ func init() {
 	rootCmd.AddCommand(getCmd)
 	getCmd.Flags().StringVarP(&resNamespace, ns, "n", "default", "namespace of the resources, not applicable to node")
 	getCmd.Flags().BoolVarP(&allNamespaces, an, "A", false, "If present, list the requested object(s) across all namespaces.")
-	getCmd.PersistentFlags().StringVarP(&dumpFile, dumpFileFlag, "f", "./cluster-info.dump", "Path to dump file")
-	getCmd.PersistentFlags().StringVarP(&dumpDir, dumpDirFlag, "d", "", "Path to dump directory")
+	getCmd.Flags().BoolVar(&showSource, "show-source", false, "If present, show the dump each object was read from.")
+	getCmd.Flags().BoolVar(&strict, "strict", false, "If present, list the objects with missing or malformed fields and exit with an error.")
+	getCmd.Flags().StringVar(&asOf, "as-of", "", "Compute ages against this RFC 3339 time instead of when the dump was taken.")
+	getPrintFlags = addOutputFlags(getCmd)
+	addSelectorFlags(getCmd)
+	getCmd.Flags().StringVar(&sortBy, "sort-by", "", "If non-empty, sort list types using this field specification. The field specification is expressed as a JSONPath expression (e.g. '{.metadata.name}').")
+	getCmd.Flags().BoolVar(&showLabels, "show-labels", false, "When printing, show all labels as the last column (default hide labels column).")
+	getCmd.Flags().StringSliceVarP(&labelColumns, "label-columns", "L", nil, "Accepts a comma separated list of labels that are going to be presented as columns. Names are case-sensitive. You can also use multiple flag options like -L label1 -L label2...")
+	addDumpFlags(getCmd)
 }
 
-func processDoc(buffer string) {
-	var result map[string]interface{}
-	// fmt.Println(buffer)
-	// fmt.Println("=====================================================\n===================================================")
-	err := json.Unmarshal([]byte(buffer), &result)
+// getRequest is a type asked for on the command line, with the names asked
+// for, if any, and the objects found.
+type getRequest struct {
+	typeName string
+	t        *resourceType
+	names    []string
+	items    []interface{}
+}
 
-	if err != nil {
-		// log.Fatalf("Error processing buffer: %v\n%v\n", err.Error(), buffer)
-		return
-	}
-	if result["kind"] == nil {
-		return
-	}
-	if result["items"] == nil {
-		return
-	}
-	// log.Print(resType+"/", resNamespace+"/", resName+"/", result["kind"].(string)+"/", resKind)
-	if result["kind"] == "List" {
-		for _, item := range result["items"].([]interface{}) {
-			obj := item.(map[string]interface{})
-			kind := obj["kind"]
-			if kind != resKind {
-				continue
-			}
-			metadata := obj["metadata"].(map[string]interface{})
-			objName := metadata["name"].(string)
-			if resName != "" && objName != resName {
-				continue
+// parseGetArgs turns the arguments of get, either TYPE[,TYPE...] [NAME...] or
+// TYPE/NAME..., into one request per type, in the order they are given. The
+// type all stands for allTypes.
+func parseGetArgs(args []string) []*getRequest {
+	requests := []*getRequest{}
+	add := func(typeName string, names ...string) {
+		t, err := lookupType(typeName)
+		if err != nil {
+			log.Fatalf("Error: %v\n", err.Error())
+		}
+		for _, r := range requests {
+			if r.t.Kind.Kind == t.Kind.Kind {
+				r.names = append(r.names, names...)
+				return
 			}
-			displayItems = append(displayItems, item)
 		}
+		requests = append(requests, &getRequest{typeName: typeName, t: t, names: append([]string{}, names...)})
+	}
 
-	} else if resKind == result["kind"].(string)[0:len(result["kind"].(string))-4] {
-		if contains(UnnamespacedTypes, resKind) {
-			for _, item := range result["items"].([]interface{}) {
-				obj := item.(map[string]interface{})
-				metadata := obj["metadata"].(map[string]interface{})
-				objName := metadata["name"].(string)
-				if resName != "" && objName != resName {
-					continue
-				}
-				displayItems = append(displayItems, item)
+	if strings.Contains(args[0], "/") {
+		for _, arg := range args {
+			parts := strings.SplitN(arg, "/", 2)
+			if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+				log.Fatalf("Error: arguments in resource/name form must have a single resource and name: %s\n", arg)
 			}
-		} else {
-			findItems(result["items"].([]interface{}))
+			add(parts[0], parts[1])
 		}
+		return requests
 	}
-}
-
-func findItems(items []interface{}) {
-	for _, item := range items {
-		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		res := item.(map[string]interface{})
-		// fmt.Println("item: ", reflect.TypeOf(node["status"]).String())
-		metadata := res["metadata"].(map[string]interface{})
-		// fmt.Printf("object ns %s pod %s \n", metadata["namespace"], metadata["name"])
-		if !allNamespaces && resNamespace != "" && resNamespace != metadata["namespace"] {
-			continue
+	names := args[1:]
+	for _, name := range names {
+		if strings.Contains(name, "/") {
+			log.Fatalf("Error: there is no need to specify a resource type as a separate argument when passing arguments in resource/name form (e.g. 'kubedmp get resource/<resource_name>' instead of 'kubedmp get resource resource/<resource_name>')\n")
 		}
-		if resName != "" && resName != metadata["name"] {
+	}
+	for _, typeName := range strings.Split(args[0], ",") {
+		if typeName == "all" {
+			for _, t := range allTypes {
+				add(t, names...)
+			}
 			continue
 		}
-		displayItems = append(displayItems, item)
+		add(typeName, names...)
 	}
+	return requests
 }
 
-func printItems() {
-	switch resType {
-	case "no", "node", "nodes":
-		prettyPrintNodeList(displayItems)
-	case "po", "pod", "pods":
-		prettyPrintPodList(displayItems)
-	case "svc", "service", "services":
-		prettyPrintServiceList(displayItems)
-	case "deploy", "deployment", "deployments":
-		prettyPrintDeploymentList(displayItems)
-	case "ds", "daemonset", "daemonsets":
-		prettyPrintDaemonSetList(displayItems)
-	case "rs", "replicaset", "replicasets":
-		prettyPrintReplicaSetList(displayItems)
-	case "sts", "statefulset", "statefulsets":
-		prettyPrintStatefulSetList(displayItems)
-	case "event", "events":
-		prettyPrintEventList(displayItems)
-	case "pv", "persistentvolume", "persistentvolumes":
-		prettyPrintPersistentVolumeList(displayItems)
-	case "pvc", "persistentvolumeclaim", "persistentvolumeclaims":
-		prettyPrintPersistentVolumeClaimList(displayItems)
-	case "secret", "secrets":
-		prettyPrintSecretList(displayItems)
-	case "cm", "configmap", "configmaps":
-		prettyPrintConfigMapList(displayItems)
-	case "sa", "serviceaccount", "serviceaccounts":
-		prettyPrintServiceAccountList(displayItems)
-	case "ing", "ingress", "ingresses":
-		prettyPrintIngressList(displayItems)
-	case "sc", "storageclass", "storageclasses":
-		prettyPrintStorageClassList(displayItems)
-	case "clusterrole", "clusterroles":
-		prettyPrintClusterRoleList(displayItems)
-	case "clusterrolebinding", "clusterrolebindings":
-		prettyPrintClusterRoleBindingList(displayItems)
-	case "ep", "endpoint", "endpoints":
-		prettyPrintEndpointsList(displayItems)
-	case "job", "jobs":
-		prettyPrintJobList(displayItems)
-	case "cj", "cronjob", "cronjobs":
-		prettyPrintCronJobList(displayItems)
-	case "role", "roles":
-		prettyPrintRoleList(displayItems)
-	case "rolebinding", "rolebindings":
-		prettyPrintRoleBindingList(displayItems)
+// getItems reads the objects of all requests from the dump at once and
+// returns a line for each name that was not found.
+func getItems(requests []*getRequest) []string {
+	namespace := resNamespace
+	if allNamespaces {
+		namespace = ""
 	}
-}
-
-func traverseDir() {
-	dirInfo, err := os.Stat(dumpDir)
-	if err != nil {
-		log.Fatalf("Error to open [dir=%v]: %v", dumpDir, err.Error())
+	kinds := []string{}
+	named := false
+	for _, r := range requests {
+		kinds = append(kinds, r.t.Kind.Kind)
+		named = named || len(r.names) > 0
 	}
-	if !dirInfo.IsDir() {
-		log.Fatalf("Path (%v) is not a dir.", dumpDir)
+	if named && hasSelector() {
+		log.Fatalf("Error: name cannot be provided when a selector is specified.")
 	}
-	filename := DumpFileNames[resKind]
-	// fmt.Println("filename: ", filename)
-	dumpDirPath, _ := filepath.Abs(dumpDir)
-	// fmt.Println("fullPath: ", dumpDirPath)
-	if strings.Contains(dumpDirPath, "sos_commands") && strings.Contains(dumpDirPath, "kubernetes") {
-		subdirs, err1 := os.ReadDir(dumpDir)
-		if err1 != nil {
-			log.Fatalf("Error to open [dir=%v]: %v", dumpDir, err1.Error())
-		}
-		for _, dir := range subdirs {
-			// fmt.Println("dir: ", dir.Name())
-			// subdirInfo, _ := os.Stat(filepath.Join(dumpDir, dir.Name()))
-			// fmt.Println("subdirInfo.Name(): ", subdirInfo.Name())
-			// fmt.Println("subdirInfo.IsDir(): ", subdirInfo.IsDir())
-			// fmt.Println("!contains(UnnamespacedTypes, resKind): ", !contains(UnnamespacedTypes, resKind))
-			// fmt.Println("subdirInfo.Name() == filename: ", subdirInfo.Name() == filename)
-			if dir.IsDir() && !contains(UnnamespacedTypes, resKind) && (allNamespaces || dir.Name() == resNamespace) {
-				resFiles, err2 := os.ReadDir(filepath.Join(dumpDir, dir.Name()))
-				if err2 != nil {
-					log.Fatalf("Error to open [dir=%v]: %v", dir.Name(), err2.Error())
-				}
-				// fmt.Println("dir.Name(): ", dir.Name())
-				for _, resFile := range resFiles {
-					if !resFile.IsDir() && strings.HasSuffix(resFile.Name(), filename) {
-						itemFilename := filepath.Join(dumpDir, dir.Name(), resFile.Name())
-						// fmt.Println("itemFilename: ", itemFilename)
-						readFile(itemFilename, processDoc)
-					}
-				}
-			} else if !dir.IsDir() && strings.HasSuffix(filepath.Base(dir.Name()), filename) {
-				itemFilename := filepath.Join(dumpDir, dir.Name())
-				if strings.Contains(itemFilename, "-o_json") {
-					// fmt.Println("itemFilename: ", itemFilename)
-					readFile(itemFilename, processDoc)
-				}
+	labelSel, fieldSel := selectors()
+	objects, err := dumpData.ListKinds(kinds, namespace, labelSel, fieldSel)
+	if err != nil {
+		log.Fatalf("Error to read dump: %v", err.Error())
+	}
+
+	missing := []string{}
+	for _, r := range requests {
+		found := []interface{}{}
+		for _, obj := range objects {
+			if obj.GetKind() == r.t.Kind.Kind {
+				found = append(found, obj.Object)
 			}
 		}
-	} else {
-		// fmt.Println("filename: ", filename)
-		if allNamespaces && !strings.HasPrefix(resType, "no") {
-			subdirs, err1 := os.ReadDir(dumpDir)
-			if err1 != nil {
-				log.Fatalf("Error to open [dir=%v]: %v", dumpDir, err1.Error())
-			}
-			for _, dir := range subdirs {
-				subdirInfo, _ := os.Stat(filepath.Join(dumpDir, dir.Name()))
-				if !subdirInfo.IsDir() {
-					continue
+		if len(r.names) == 0 {
+			r.items = found
+			continue
+		}
+		for _, name := range r.names {
+			seen := false
+			for _, item := range found {
+				if newObject(r.t.Kind.Kind, item).str("metadata", "name") == name {
+					r.items = append(r.items, item)
+					seen = true
 				}
-				itemFilename := filepath.Join(dumpDir, dir.Name(), filename+"."+dumpFormat)
-				readFile(itemFilename, processDoc)
 			}
-		} else {
-			if _, err1 := os.Stat(filepath.Join(dumpDir, resNamespace)); os.IsNotExist(err1) {
-				log.Fatalf("namespace %v does not exist: %v", resNamespace, err1.Error())
+			if !seen {
+				missing = append(missing, fmt.Sprintf("%s %s is not found.", r.typeName, name))
 			}
-			itemFilename := filepath.Join(dumpDir, resNamespace, filename+"."+dumpFormat)
-			// fmt.Println("itemFilename: ", itemFilename)
-			readFile(itemFilename, processDoc)
 		}
 	}
+	return missing
+}
+
+// printItems prints a table for each request, one after another as kubectl
+// get does. Types without objects are left out when several are asked for.
+func printItems(requests []*getRequest) {
+	printed := false
+	for _, r := range requests {
+		if len(r.items) == 0 && (len(requests) > 1 || len(r.names) > 0) {
+			continue
+		}
+		if printed {
+			fmt.Println()
+		}
+		resource = r.t
+		r.t.print(r.items)
+		printed = true
+	}
 }
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxHPAMetrics is the number of metrics the TARGETS column of an HPA shows
+// before it sums up the rest, as kubectl does.
+const maxHPAMetrics = 2
+
+// hpaMetrics returns the TARGETS column of an autoscaling/v2 HPA: the current
+// and target value of each of its metrics, such as 45%/80% for the average
+// CPU utilization. Values not reported yet are <unknown>.
+func hpaMetrics(hpa *object) string {
+	specs := hpa.objects("spec", "metrics")
+	if len(specs) == 0 {
+		return none
+	}
+	statuses := hpa.objects("status", "currentMetrics")
+	list := []string{}
+	for i, spec := range specs {
+		status := &object{item: hpa.item, kind: hpa.kind}
+		if i < len(statuses) {
+			status = statuses[i]
+		}
+		switch metricType := spec.str("type"); metricType {
+		case "External", "Object", "Pods":
+			source := strings.ToLower(metricType[:1]) + metricType[1:]
+			target := spec.child(source, "target")
+			if target.has("averageValue") {
+				current := quantityOrUnknown(status, source, "current", "averageValue")
+				format := "%s/%s (avg)"
+				if metricType == "Pods" {
+					format = "%s/%s"
+				}
+				list = append(list, fmt.Sprintf(format, current, target.text("averageValue")))
+			} else {
+				list = append(list, fmt.Sprintf("%s/%s", quantityOrUnknown(status, source, "current", "value"), target.text("value")))
+			}
+		case "Resource", "ContainerResource":
+			source := strings.ToLower(metricType[:1]) + metricType[1:]
+			target := spec.child(source, "target")
+			if target.has("averageValue") {
+				list = append(list, fmt.Sprintf("%s/%s", quantityOrUnknown(status, source, "current", "averageValue"), target.text("averageValue")))
+				continue
+			}
+			current := "<unknown>"
+			if status.has(source, "current", "averageUtilization") {
+				current = fmt.Sprintf("%d%%", status.num(source, "current", "averageUtilization"))
+			}
+			targetUtilization := "<auto>"
+			if target.has("averageUtilization") {
+				targetUtilization = fmt.Sprintf("%d%%", target.num("averageUtilization"))
+			}
+			list = append(list, fmt.Sprintf("%s/%s", current, targetUtilization))
+		default:
+			list = append(list, "<unknown type>")
+		}
+	}
+	if len(list) > maxHPAMetrics {
+		return fmt.Sprintf("%s + %d more...", strings.Join(list[:maxHPAMetrics], ", "), len(list)-maxHPAMetrics)
+	}
+	return strings.Join(list, ", ")
+}
+
+// quantityOrUnknown returns a quantity field of a metric status, or
+// <unknown> if the metric has not been read yet.
+func quantityOrUnknown(status *object, fields ...string) string {
+	if !status.has(fields...) {
+		return "<unknown>"
+	}
+	return status.text(fields...)
+}
@@ -0,0 +1,114 @@
+package cli
+
+import "testing"
+
+// The expected columns are those kubectl get hpa prints for the same HPAs.
+func TestHPAMetrics(t *testing.T) {
+	tests := []struct {
+		name string
+		hpa  string
+		want string
+	}{
+		{
+			name: "no metrics",
+			hpa:  `spec: {}`,
+			want: "<none>",
+		},
+		{
+			name: "resource utilization",
+			hpa: `
+spec: {metrics: [{type: Resource, resource: {name: cpu, target: {type: Utilization, averageUtilization: 80}}}]}
+status: {currentMetrics: [{type: Resource, resource: {name: cpu, current: {averageUtilization: 45, averageValue: 90m}}}]}`,
+			want: "45%/80%",
+		},
+		{
+			name: "resource utilization not read yet",
+			hpa: `
+spec: {metrics: [{type: Resource, resource: {name: cpu, target: {type: Utilization, averageUtilization: 80}}}]}`,
+			want: "<unknown>/80%",
+		},
+		{
+			name: "resource utilization without a target",
+			hpa: `
+spec: {metrics: [{type: Resource, resource: {name: cpu, target: {type: Utilization}}}]}
+status: {currentMetrics: [{type: Resource, resource: {name: cpu, current: {averageUtilization: 45}}}]}`,
+			want: "45%/<auto>",
+		},
+		{
+			name: "resource average value",
+			hpa: `
+spec: {metrics: [{type: Resource, resource: {name: memory, target: {type: AverageValue, averageValue: 500Mi}}}]}
+status: {currentMetrics: [{type: Resource, resource: {name: memory, current: {averageValue: 300Mi}}}]}`,
+			want: "300Mi/500Mi",
+		},
+		{
+			name: "container resource",
+			hpa: `
+spec: {metrics: [{type: ContainerResource, containerResource: {name: cpu, container: app, target: {type: Utilization, averageUtilization: 60}}}]}
+status: {currentMetrics: [{type: ContainerResource, containerResource: {name: cpu, container: app, current: {averageUtilization: 30}}}]}`,
+			want: "30%/60%",
+		},
+		{
+			name: "pods",
+			hpa: `
+spec: {metrics: [{type: Pods, pods: {metric: {name: rps}, target: {type: AverageValue, averageValue: "100"}}}]}
+status: {currentMetrics: [{type: Pods, pods: {metric: {name: rps}, current: {averageValue: "42"}}}]}`,
+			want: "42/100",
+		},
+		{
+			name: "pods not read yet",
+			hpa: `
+spec: {metrics: [{type: Pods, pods: {metric: {name: rps}, target: {type: AverageValue, averageValue: "100"}}}]}`,
+			want: "<unknown>/100",
+		},
+		{
+			name: "object value",
+			hpa: `
+spec: {metrics: [{type: Object, object: {metric: {name: hits}, describedObject: {kind: Ingress, name: web}, target: {type: Value, value: 10k}}}]}
+status: {currentMetrics: [{type: Object, object: {metric: {name: hits}, current: {value: 2k}}}]}`,
+			want: "2k/10k",
+		},
+		{
+			name: "object average value",
+			hpa: `
+spec: {metrics: [{type: Object, object: {metric: {name: hits}, describedObject: {kind: Ingress, name: web}, target: {type: AverageValue, averageValue: "50"}}}]}
+status: {currentMetrics: [{type: Object, object: {metric: {name: hits}, current: {averageValue: "20"}}}]}`,
+			want: "20/50 (avg)",
+		},
+		{
+			name: "external value not read yet",
+			hpa: `
+spec: {metrics: [{type: External, external: {metric: {name: queue}, target: {type: Value, value: "30"}}}]}`,
+			want: "<unknown>/30",
+		},
+		{
+			name: "external average value",
+			hpa: `
+spec: {metrics: [{type: External, external: {metric: {name: queue}, target: {type: AverageValue, averageValue: "5"}}}]}
+status: {currentMetrics: [{type: External, external: {metric: {name: queue}, current: {averageValue: "7"}}}]}`,
+			want: "7/5 (avg)",
+		},
+		{
+			name: "more metrics than shown",
+			hpa: `
+spec:
+  metrics:
+  - {type: Resource, resource: {name: cpu, target: {type: Utilization, averageUtilization: 80}}}
+  - {type: Resource, resource: {name: memory, target: {type: Utilization, averageUtilization: 70}}}
+  - {type: Pods, pods: {metric: {name: rps}, target: {type: AverageValue, averageValue: "100"}}}
+  - {type: External, external: {metric: {name: queue}, target: {type: Value, value: "30"}}}
+status:
+  currentMetrics:
+  - {type: Resource, resource: {name: cpu, current: {averageUtilization: 45}}}
+  - {type: Resource, resource: {name: memory, current: {averageUtilization: 50}}}`,
+			want: "45%/80%, 50%/70% + 2 more...",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := hpaMetrics(testObject(t, "HorizontalPodAutoscaler", test.hpa)); got != test.want {
+				t.Errorf("hpaMetrics = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
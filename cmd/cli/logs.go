@@ -1,14 +1,12 @@
 package cli
 
 import (
-	"bufio"
-	"fmt"
+	"errors"
 	"io"
 	"log"
 	"os"
-	"path/filepath"
-	"strings"
 
+	"github.com/shundezhang/kubedmp/pkg/dump"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +15,7 @@ const (
 )
 
 var logsCmd = &cobra.Command{
-	Use:                   "logs POD_NAME [-n NAMESPACE] [-c CONTAINER_NAME]",
+	Use:                   "logs (POD_NAME | -l label) [-n NAMESPACE] [-c CONTAINER_NAME]",
 	DisableFlagsInUseLine: true,
 	Short:                 "Print the logs for a container in a pod",
 	Long: `Print the logs for a container in a pod or specified resource.
@@ -26,7 +24,10 @@ If the pod has more than one container, and a container name is not specified, l
   kubedmp logs nginx
   
   # Return logs of ruby container logs from pod web-1
-  kubectl logs web-1 -c ruby`,
+  kubectl logs web-1 -c ruby
+
+  # Return logs of all pods of app web
+  kubedmp logs -l app=web`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// dumpFile, err := cmd.Flags().GetString(dumpFileFlag)
 		// if err != nil {
@@ -34,11 +35,10 @@ If the pod has more than one container, and a container name is not specified, l
 		// 	return
 		// }
 
-		if len(args) == 0 {
-			log.Fatalf("Please provide a pod name.\n")
+		if len(args) == 0 && !hasSelector() {
+			log.Fatalf("Please provide a pod name or a selector.\n")
 			return
 		}
-		podName := args[0]
 
 		// container, err := cmd.Flags().GetString(cont)
 		// if err != nil {
@@ -46,102 +46,52 @@ If the pod has more than one container, and a container name is not specified, l
 		// 	return
 		// }
 		// fmt.Printf("parsing dump file %s\n", dumpFile)
-		logFile := ""
-		marker := resNamespace + "/" + podName
-		if len(resContainer) > 0 {
-			marker = "container " + resContainer + " of pod " + resNamespace + "/" + podName
-		}
-
-		if len(dumpDir) > 0 {
-			dumpDirPath, _ := filepath.Abs(dumpDir)
-			// fmt.Println("fullPath: ", dumpDirPath)
-			if strings.Contains(dumpDirPath, "sos_commands") && strings.Contains(dumpDirPath, "kubernetes") {
-				marker = ""
-				podFiles, err1 := os.ReadDir(filepath.Join(dumpDir, resNamespace, "podlogs", podName))
-				if err1 != nil {
-					log.Fatalf("Error to open [dir=%v]: %v", dumpDir, err1.Error())
-				}
-				if len(resContainer) == 0 && len(podFiles) > 1 {
-					log.Fatalf("Please specify a container name since this pod %s/%s has more than one containers.", resNamespace, podName)
-				} else {
-					for _, podFile := range podFiles {
-						// fmt.Println("podFile: ", podFile.Name())
-						if podFile.IsDir() {
-							continue
-						}
-						// fmt.Println("search string: ", "_--namespace_"+resNamespace+"_logs_"+podName+"_-c_")
-						// fmt.Println("in name: ", strings.Contains(podFile.Name(), "_--namespace_"+resNamespace+"_logs_"+podName+"_-c_"))
-						if (len(resContainer) > 0 && strings.HasSuffix(podFile.Name(), "_--namespace_"+resNamespace+"_logs_"+podName+"_-c_"+resContainer)) ||
-							len(resContainer) == 0 {
-							logFile = filepath.Join(dumpDir, resNamespace, "podlogs", podName, podFile.Name())
-							// fmt.Println("logFile:", logFile)
-							break
-						}
-					}
-				}
-			} else {
-				logFile = filepath.Join(dumpDir, resNamespace, podName, "logs.txt")
+		openDump()
+		if len(args) > 0 {
+			if hasSelector() {
+				log.Fatalf("Error: only one of a pod name or a selector can be given.")
 			}
-		} else {
-			logFile = dumpFile
-		}
-		if len(logFile) == 0 {
-			log.Fatalf("No log is found for pod %s/%s.", resNamespace, podName)
+			printLogs(args[0], false)
+			return
 		}
-		f, err := os.Open(logFile)
+		labelSel, fieldSel := selectors()
+		pods, err := dumpData.List("Pod", resNamespace, labelSel, fieldSel)
 		if err != nil {
-			log.Fatalf("Error to read [file=%v]: %v", logFile, err.Error())
+			log.Fatalf("Error to read dump: %v", err.Error())
 		}
-
-		finishedCh := make(chan bool, 1)
-		buff := make(chan string, 100)
-		go scanFile(f, marker, buff, finishedCh)
-		defer func() {
-			f.Close()
-		}()
-		for {
-			lastLine, ok := <-buff
-
-			if ok == false {
-				break
-			}
-			fmt.Println(lastLine)
+		if len(pods) == 0 {
+			log.Fatalf("No pod in namespace %s matches the selector.", resNamespace)
+		}
+		for _, pod := range pods {
+			printLogs(pod.GetName(), true)
 		}
-
 	},
 }
 
+// printLogs prints the logs of podName. A pod without logs is fatal unless
+// skipMissing is set, when logs of several pods are printed.
+func printLogs(podName string, skipMissing bool) {
+	r, err := dumpData.Logs(resNamespace, podName, resContainer)
+	if errors.Is(err, dump.ErrNotFound) {
+		if skipMissing {
+			log.Printf("No log is found for pod %s/%s.", resNamespace, podName)
+			return
+		}
+		log.Fatalf("No log is found for pod %s/%s.", resNamespace, podName)
+	}
+	if err != nil {
+		log.Fatalf("Error to read logs: %v", err.Error())
+	}
+	defer r.Close()
+	if _, err := io.Copy(os.Stdout, r); err != nil {
+		log.Fatalf("Error while reading logs: %v", err)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(logsCmd)
 	logsCmd.Flags().StringVarP(&resNamespace, ns, "n", "default", "namespace of the pod")
 	logsCmd.Flags().StringVarP(&resContainer, cont, "c", "", "container")
-	logsCmd.PersistentFlags().StringVarP(&dumpFile, dumpFileFlag, "f", "./cluster-info.dump", "Path to dump file")
-	logsCmd.PersistentFlags().StringVarP(&dumpDir, dumpDirFlag, "d", "", "Path to dump directory")
-}
-
-func scanFile(f *os.File, marker string, buff chan string, finishedCh chan bool) {
-	reader := bufio.NewReader(f)
-	canPrint := false
-	for {
-		line, err := reader.ReadString('\n')
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			log.Fatalf("Error while reading FD %v: %v", f.Fd(), err)
-			break
-		}
-		line = strings.TrimSuffix(line, "\n")
-		if len(marker) == 0 || (strings.HasPrefix(line, "==== START logs for container") && strings.Contains(line, marker)) {
-			canPrint = true
-		}
-		if canPrint {
-			buff <- line
-			// fmt.Println(line)
-		}
-		if len(marker) > 0 && strings.HasPrefix(line, "==== END logs for container") && strings.Contains(line, marker) {
-			canPrint = false
-		}
-	}
-	close(buff)
+	addSelectorFlags(logsCmd)
+	addDumpFlags(logsCmd)
 }
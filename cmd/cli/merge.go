@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"github.com/shundezhang/kubedmp/pkg/dump"
+)
+
+var showSource bool
+
+// itemSource returns the dump item was read from.
+func itemSource(item interface{}) string {
+	if obj, ok := item.(map[string]interface{}); ok {
+		return dump.Source(obj)
+	}
+	return ""
+}
+
+// sourceHeader and sourceColumn prefix the rows of a list with the dump
+// each object came from when --show-source is set.
+func sourceHeader() string {
+	if !showSource {
+		return ""
+	}
+	return "SOURCE\t"
+}
+
+func sourceColumn(item interface{}) string {
+	if !showSource {
+		return ""
+	}
+	return itemSource(item) + "\t"
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/shundezhang/kubedmp/pkg/dump"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/kubectl/pkg/cmd/get"
+)
+
+// wide is set by -o wide; printers add the columns kubectl shows only in
+// wide output.
+var wide bool
+
+// addOutputFlags adds -o and the flags of the printers it selects to cmd.
+func addOutputFlags(cmd *cobra.Command) *genericclioptions.PrintFlags {
+	printFlags := genericclioptions.NewPrintFlags("").WithTypeSetter(scheme.Scheme)
+	printFlags.AddFlags(cmd)
+	formats := append([]string{"wide", "custom-columns", "custom-columns-file"}, printFlags.AllowedFormats()...)
+	cmd.Flags().Lookup("output").Usage = fmt.Sprintf("Output format. One of: (%s).", strings.Join(formats, ", "))
+	return printFlags
+}
+
+// printObjects prints items in the format given with -o and reports whether
+// it did; without -o, or with -o wide, the caller prints them as a table.
+// A single object asked for by name is printed on its own, a list of objects
+// as a List, as kubectl does.
+func printObjects(printFlags *genericclioptions.PrintFlags, items []interface{}) bool {
+	format := *printFlags.OutputFormat
+	templateSet := printFlags.TemplatePrinterFlags.TemplateArgument != nil && len(*printFlags.TemplatePrinterFlags.TemplateArgument) > 0
+	wide = format == "wide"
+	if (len(format) == 0 && !templateSet) || wide {
+		return false
+	}
+
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"metadata":   map[string]interface{}{"resourceVersion": ""},
+	}}
+	for _, item := range items {
+		data, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		obj := map[string]interface{}{}
+		for k, v := range data {
+			if k != dump.SourceKey && k != dump.SynthesizedKey {
+				obj[k] = v
+			}
+		}
+		list.Items = append(list.Items, unstructured.Unstructured{Object: obj})
+	}
+	var output runtime.Object = list
+	if len(resName) > 0 && len(list.Items) == 1 {
+		output = &list.Items[0]
+	}
+
+	var printer printers.ResourcePrinter
+	var err error
+	switch {
+	case strings.HasPrefix(format, "custom-columns="):
+		printer, err = get.NewCustomColumnsPrinterFromSpec(strings.TrimPrefix(format, "custom-columns="), unstructured.UnstructuredJSONScheme, false)
+	case strings.HasPrefix(format, "custom-columns-file="):
+		file, openErr := os.Open(strings.TrimPrefix(format, "custom-columns-file="))
+		if openErr != nil {
+			log.Fatalf("Error to read custom columns: %v", openErr.Error())
+		}
+		defer file.Close()
+		printer, err = get.NewCustomColumnsPrinterFromTemplate(file, unstructured.UnstructuredJSONScheme)
+	default:
+		printer, err = printFlags.ToPrinter()
+	}
+	if err != nil {
+		log.Fatalf("Error: %v", err.Error())
+	}
+	if err := printer.PrintObj(output, os.Stdout); err != nil {
+		log.Fatalf("Error to print %s: %v", resType, err.Error())
+	}
+	return true
+}
@@ -12,6 +12,8 @@ import (
 	"text/tabwriter"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -19,49 +21,26 @@ type PickItem func(string, string, string, string) []interface{}
 
 func prettyPrintCronJobList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tSCHEDULE\tSUSPEND\tACTIVE\tLAST SCHEDULE\tAGE\tCONTAINERS\tIMAGES\tSELECTOR")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tSCHEDULE\tSUSPEND\tACTIVE\tLAST SCHEDULE\tAGE\tCONTAINERS\tIMAGES\tSELECTOR"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		job := item.(map[string]interface{})
-		metadata := job["metadata"].(map[string]interface{})
-		spec := job["spec"].(map[string]interface{})
-		// fmt.Println("item: ", reflect.TypeOf(node["status"]).String())
-		jobTemplate := spec["jobTemplate"].(map[string]interface{})
-		jobTemplateSpec := jobTemplate["spec"].(map[string]interface{})
-		template := jobTemplateSpec["template"].(map[string]interface{})
-		templateSpec := template["spec"].(map[string]interface{})
-		status := job["status"].(map[string]interface{})
-
-		creationTimeStr := metadata["creationTimestamp"].(string)
-
-		lastScheduleTimeStr := status["lastScheduleTime"].(string)
-		lastSched := getAge(lastScheduleTimeStr)
-
-		selectorStr := "<none>"
-		selectorList := []string{}
-		if selector, ok := spec["selector"].(map[string]interface{}); ok {
-			if matchLabels, ok1 := selector["matchLabels"].(map[string]interface{}); ok1 {
-				for k, v := range matchLabels {
-					selectorList = append(selectorList, k+"="+v.(string))
-				}
-				selectorStr = strings.Join(selectorList, ",")
-			}
-		}
-		age := getAge(creationTimeStr)
+		job := newObject("CronJob", item)
+		templateSpec := job.child("spec", "jobTemplate", "spec", "template", "spec")
+
+		lastSched := getAge(job.str("status", "lastScheduleTime"))
+		selectorStr := orNone(strings.Join(job.pairs("spec", "selector", "matchLabels"), ","))
+		age := getAge(job.str("metadata", "creationTimestamp"))
 		imageList := []string{}
 		containerList := []string{}
-		containers := templateSpec["containers"].([]interface{})
-		for _, item1 := range containers {
-			cont := item1.(map[string]interface{})
-			containerList = append(containerList, cont["name"].(string))
-			imageList = append(imageList, cont["image"].(string))
+		for _, cont := range templateSpec.objects("containers") {
+			containerList = append(containerList, cont.str("name"))
+			imageList = append(imageList, cont.str("image"))
 		}
 
-		active := 0
-		if activeList, ok := status["active"].([]interface{}); ok {
-			active = len(activeList)
-		}
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%t\t%d\t%s\t%s\t%s\t%s\t%s\n", metadata["namespace"], metadata["name"], spec["schedule"], spec["suspend"], active, lastSched, age, strings.Join(containerList, ","), strings.Join(imageList, ","), selectorStr)
+		active := len(job.list("status", "active"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%t\t%d\t%s\t%s\t%s\t%s\t%s%s\n", job.text("metadata", "namespace"), job.name(), job.text("spec", "schedule"), job.flag("spec", "suspend"), active, lastSched, age, orNone(strings.Join(containerList, ",")), orNone(strings.Join(imageList, ",")), selectorStr, labelValues(item))
 	}
 	writer.Flush()
 
@@ -69,71 +48,64 @@ func prettyPrintCronJobList(items []interface{}) {
 
 func prettyPrintJobList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tCOMPLETIONS\tDURATION\tAGE\tCONTAINERS\tIMAGES\tSELECTOR")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tCOMPLETIONS\tDURATION\tAGE\tCONTAINERS\tIMAGES\tSELECTOR"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		job := item.(map[string]interface{})
-		metadata := job["metadata"].(map[string]interface{})
-		spec := job["spec"].(map[string]interface{})
-		// fmt.Println("item: ", reflect.TypeOf(node["status"]).String())
-		template := spec["template"].(map[string]interface{})
-		templateSpec := template["spec"].(map[string]interface{})
-		status := job["status"].(map[string]interface{})
-		succeeded := "0"
-		if status["succeeded"] != nil {
-			succeeded = strconv.FormatInt(int64(status["succeeded"].(float64)), 10)
-		}
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		startTimeStr := status["startTime"].(string)
+		job := newObject("Job", item)
+		templateSpec := job.child("spec", "template", "spec")
+		succeeded := strconv.FormatInt(job.num("status", "succeeded"), 10)
+		startTimeStr := job.str("status", "startTime")
 		duration := getAge(startTimeStr)
 
-		if status["completionTime"] != nil {
-			duration = getDuration(startTimeStr, status["completionTime"].(string))
+		if job.has("status", "completionTime") {
+			duration = getDuration(startTimeStr, job.str("status", "completionTime"))
 		}
-		selectorStr := "<none>"
-		selectorList := []string{}
-		if selector, ok := spec["selector"].(map[string]interface{}); ok {
-			if matchLabels, ok1 := selector["matchLabels"].(map[string]interface{}); ok1 {
-				for k, v := range matchLabels {
-					selectorList = append(selectorList, k+"="+v.(string))
-				}
-				selectorStr = strings.Join(selectorList, ",")
-			}
-		}
-		age := getAge(creationTimeStr)
+		selectorStr := orNone(strings.Join(job.pairs("spec", "selector", "matchLabels"), ","))
+		age := getAge(job.str("metadata", "creationTimestamp"))
 		imageList := []string{}
 		containerList := []string{}
-		containers := templateSpec["containers"].([]interface{})
-		for _, item1 := range containers {
-			cont := item1.(map[string]interface{})
-			containerList = append(containerList, cont["name"].(string))
-			imageList = append(imageList, cont["image"].(string))
-		}
-		completions := "0"
-		if spec["completions"] != nil {
-			completions = strconv.FormatInt(int64((spec["completions"].(float64))), 10)
-		}
-		fmt.Fprintf(writer, "%s\t%s\t%s/%s\t%s\t%s\t%s\t%s\t%s\n", metadata["namespace"], metadata["name"], succeeded, completions, duration, age, strings.Join(containerList, ","), strings.Join(imageList, ","), selectorStr)
+		for _, cont := range templateSpec.objects("containers") {
+			containerList = append(containerList, cont.str("name"))
+			imageList = append(imageList, cont.str("image"))
+		}
+		// as kubectl, a job without completions is done when one pod succeeds
+		completions := "1"
+		if job.has("spec", "completions") {
+			completions = strconv.FormatInt(job.num("spec", "completions"), 10)
+		} else if parallelism := job.num("spec", "parallelism"); parallelism > 1 {
+			completions = fmt.Sprintf("1 of %d", parallelism)
+		}
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s/%s\t%s\t%s\t%s\t%s\t%s%s\n", job.text("metadata", "namespace"), job.name(), succeeded, completions, duration, age, orNone(strings.Join(containerList, ",")), orNone(strings.Join(imageList, ",")), selectorStr, labelValues(item))
 	}
 	writer.Flush()
 
 }
 
+func prettyPrintNamespaceList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAME\tSTATUS\tAGE"+labelHeaders())
+	for _, item := range items {
+		ns := newObject("Namespace", item)
+		age := getAge(ns.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s%s\n", ns.name(), ns.text("status", "phase"), age, labelValues(item))
+	}
+	writer.Flush()
+}
+
 func prettyPrintStorageClassList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAME\tPROVISIONER\tRECLAIMPOLICY\tVOLUMEBINDINGMODE\tALLOWVOLUMEEXPANSION\tAGE")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAME\tPROVISIONER\tRECLAIMPOLICY\tVOLUMEBINDINGMODE\tALLOWVOLUMEEXPANSION\tAGE"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		sc := item.(map[string]interface{})
-		metadata := sc["metadata"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
-		allowVolumeExpansion := false
-		if sc["allowVolumeExpansion"] != nil {
-			allowVolumeExpansion = sc["allowVolumeExpansion"].(bool)
-		}
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%t\t%s\n", metadata["name"], sc["provisioner"], sc["reclaimPolicy"], sc["volumeBindingMode"], allowVolumeExpansion, age)
+		sc := newObject("StorageClass", item)
+		age := getAge(sc.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%t\t%s%s\n", sc.name(), sc.text("provisioner"), sc.text("reclaimPolicy"), sc.text("volumeBindingMode"), sc.flag("allowVolumeExpansion"), age, labelValues(item))
 	}
 	writer.Flush()
 
@@ -141,15 +113,14 @@ func prettyPrintStorageClassList(items []interface{}) {
 
 func prettyPrintClusterRoleList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAME\tCREATED AT")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAME\tCREATED AT"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		cr := item.(map[string]interface{})
-		metadata := cr["metadata"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
+		cr := newObject("ClusterRole", item)
 
-		fmt.Fprintf(writer, "%s\t%s\n", metadata["name"], creationTimeStr)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s%s\n", cr.name(), cr.text("metadata", "creationTimestamp"), labelValues(item))
 	}
 	writer.Flush()
 
@@ -157,15 +128,14 @@ func prettyPrintClusterRoleList(items []interface{}) {
 
 func prettyPrintRoleList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tCREATED AT")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tCREATED AT"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		cr := item.(map[string]interface{})
-		metadata := cr["metadata"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
+		cr := newObject("Role", item)
 
-		fmt.Fprintf(writer, "%s\t%s\t%s\n", metadata["namespace"], metadata["name"], creationTimeStr)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s%s\n", cr.text("metadata", "namespace"), cr.name(), cr.text("metadata", "creationTimestamp"), labelValues(item))
 	}
 	writer.Flush()
 
@@ -173,33 +143,15 @@ func prettyPrintRoleList(items []interface{}) {
 
 func prettyPrintClusterRoleBindingList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAME\tROLE\tAGE\tUSERS\tGROUPS\tSERVICEACCOUNTS")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAME\tROLE\tAGE\tUSERS\tGROUPS\tSERVICEACCOUNTS"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		crb := item.(map[string]interface{})
-		metadata := crb["metadata"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		age := getAge(creationTimeStr)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		role := crb["roleRef"].(map[string]interface{})
-		user := ""
-		group := ""
-		sa := ""
-		if subjects, ok := crb["subjects"].([]interface{}); ok {
-			for _, item1 := range subjects {
-				sub := item1.(map[string]interface{})
-				if sub["kind"] == "ServiceAccount" {
-					sa += sub["namespace"].(string) + "/" + sub["name"].(string) + " "
-				}
-				if sub["kind"] == "Group" {
-					group += sub["name"].(string) + " "
-				}
-				if sub["kind"] == "User" {
-					user += sub["name"].(string) + " "
-				}
-			}
-		}
-		fmt.Fprintf(writer, "%s\t%s/%s\t%s\t%s\t%s\t%s\n", metadata["name"], role["kind"], role["name"], age, strings.Replace(strings.Trim(user, " "), " ", ",", -1), strings.Replace(strings.Trim(group, " "), " ", ",", -1), strings.Replace(strings.Trim(sa, " "), " ", ",", -1))
+		crb := newObject("ClusterRoleBinding", item)
+		age := getAge(crb.str("metadata", "creationTimestamp"))
+		user, group, sa := bindingSubjects(crb)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s/%s\t%s\t%s\t%s\t%s%s\n", crb.name(), crb.text("roleRef", "kind"), crb.text("roleRef", "name"), age, user, group, sa, labelValues(item))
 	}
 	writer.Flush()
 
@@ -207,203 +159,134 @@ func prettyPrintClusterRoleBindingList(items []interface{}) {
 
 func prettyPrintRoleBindingList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tROLE\tAGE\tUSERS\tGROUPS\tSERVICEACCOUNTS")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tROLE\tAGE\tUSERS\tGROUPS\tSERVICEACCOUNTS"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		crb := item.(map[string]interface{})
-		metadata := crb["metadata"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		age := getAge(creationTimeStr)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		role := crb["roleRef"].(map[string]interface{})
-		user := ""
-		group := ""
-		sa := ""
-		if subjects, ok := crb["subjects"].([]interface{}); ok {
-			for _, item1 := range subjects {
-				sub := item1.(map[string]interface{})
-				if sub["kind"] == "ServiceAccount" {
-					sa += sub["namespace"].(string) + "/" + sub["name"].(string) + " "
-				}
-				if sub["kind"] == "Group" {
-					group += sub["name"].(string) + " "
-				}
-				if sub["kind"] == "User" {
-					user += sub["name"].(string) + " "
-				}
-			}
-		}
-		fmt.Fprintf(writer, "%s\t%s\t%s/%s\t%s\t%s\t%s\t%s\n", metadata["namespace"], metadata["name"], role["kind"], role["name"], age, strings.Replace(strings.Trim(user, " "), " ", ",", -1), strings.Replace(strings.Trim(group, " "), " ", ",", -1), strings.Replace(strings.Trim(sa, " "), " ", ",", -1))
+		rb := newObject("RoleBinding", item)
+		age := getAge(rb.str("metadata", "creationTimestamp"))
+		user, group, sa := bindingSubjects(rb)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s/%s\t%s\t%s\t%s\t%s%s\n", rb.text("metadata", "namespace"), rb.name(), rb.text("roleRef", "kind"), rb.text("roleRef", "name"), age, user, group, sa, labelValues(item))
 	}
 	writer.Flush()
 
 }
 
+// bindingSubjects returns the users, groups and service accounts a role
+// binding refers to.
+func bindingSubjects(binding *object) (string, string, string) {
+	user := ""
+	group := ""
+	sa := ""
+	for _, sub := range binding.objects("subjects") {
+		switch sub.str("kind") {
+		case "ServiceAccount":
+			sa += sub.str("namespace") + "/" + sub.str("name") + " "
+		case "Group":
+			group += sub.str("name") + " "
+		case "User":
+			user += sub.str("name") + " "
+		}
+	}
+	return strings.Replace(strings.Trim(user, " "), " ", ",", -1), strings.Replace(strings.Trim(group, " "), " ", ",", -1), strings.Replace(strings.Trim(sa, " "), " ", ",", -1)
+}
+
 func prettyPrintNodeList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAME\tSTATUS\tROLES\tAGE\tVERSION\tINTERNAL-IP\tEXTERNAL-IP\tOS-IMAGE\tKERNEL-VERSION\tCONTAINER-RUNTIME")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAME\tSTATUS\tROLES\tAGE\tVERSION\tINTERNAL-IP\tEXTERNAL-IP\tOS-IMAGE\tKERNEL-VERSION\tCONTAINER-RUNTIME"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		node := item.(map[string]interface{})
-		metadata := node["metadata"].(map[string]interface{})
-		nodeName := metadata["name"].(string)
-		spec := node["spec"].(map[string]interface{})
-		// fmt.Println("item: ", reflect.TypeOf(node["status"]).String())
-		status := node["status"].(map[string]interface{})
-		addresses := status["addresses"].([]interface{})
-		conditions := status["conditions"].([]interface{})
-		nodeInfo := status["nodeInfo"].(map[string]interface{})
-		kubletVersion := nodeInfo["kubeletVersion"].(string)
-		kernelVersion := nodeInfo["kernelVersion"].(string)
-		osImage := nodeInfo["osImage"].(string)
-		containerRuntimeVersion := nodeInfo["containerRuntimeVersion"].(string)
-		// address := item.(map[string]interface{})["status"]["addresses"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
-		labels := metadata["labels"].(map[string]interface{})
+		node := newObject("Node", item)
+		nodeInfo := node.child("status", "nodeInfo")
+		age := getAge(node.str("metadata", "creationTimestamp"))
 		role := "<none>"
 		roles := []string{}
-		for r := range labels {
+		for r := range node.dict("metadata", "labels") {
 			if strings.HasPrefix(r, "node-role.kubernetes.io/") {
 				roles = append(roles, strings.Split(r, "/")[1])
 			}
 		}
 		if len(roles) > 0 {
+			sort.Strings(roles)
 			role = strings.Join(roles, ",")
 		}
-		// var hostname string
-		var ipaddress string
+		ipaddress := "<none>"
 		extip := "<none>"
-		for _, address := range addresses {
+		for _, address := range node.objects("status", "addresses") {
 			// fmt.Println("address: ", address)
-			add := address.(map[string]interface{})
-			if add["type"] == "InternalIP" {
-				ipaddress = add["address"].(string)
-			} else if add["type"] == "ExternalIP" {
-				extip = add["address"].(string)
-				// } else if add["type"] == "Hostname" {
-				// 	hostname = add["address"].(string)
+			if address.str("type") == "InternalIP" {
+				ipaddress = address.text("address")
+			} else if address.str("type") == "ExternalIP" {
+				extip = address.text("address")
 			}
 		}
 		var state string
-		for _, condition := range conditions {
-			cond := condition.(map[string]interface{})
-			if cond["status"] == "True" {
-				state += cond["type"].(string) + " "
+		for _, cond := range node.objects("status", "conditions") {
+			if cond.str("status") == "True" {
+				state += cond.str("type") + " "
 			}
 		}
-		if spec["unschedulable"] != nil && spec["unschedulable"].(bool) {
+		if node.flag("spec", "unschedulable") {
 			state += "SchedulingDisabled "
 		}
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", nodeName, strings.Replace(strings.Trim(state, " "), " ", ",", -1), role, age, kubletVersion, ipaddress, extip, osImage, kernelVersion, containerRuntimeVersion)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s%s\n", node.name(), orNone(strings.Replace(strings.Trim(state, " "), " ", ",", -1)), role, age, nodeInfo.text("kubeletVersion"), ipaddress, extip, nodeInfo.text("osImage"), nodeInfo.text("kernelVersion"), nodeInfo.text("containerRuntimeVersion"), labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintPodList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tREADY\tSTATUS\tRESTARTS\tAGE\tIP\tNODE")
+	fmt.Fprint(writer, sourceHeader())
+	header := "NAMESPACE\tNAME\tREADY\tSTATUS\tRESTARTS\tAGE\tIP\tNODE"
+	if wide {
+		header += "\tNOMINATED NODE\tREADINESS GATES"
+	}
+	fmt.Fprintln(writer, header+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		pod := item.(map[string]interface{})
-		// fmt.Println("item: ", reflect.TypeOf(node["status"]).String())
-		metadata := pod["metadata"].(map[string]interface{})
-		status := pod["status"].(map[string]interface{})
-		spec := pod["spec"].(map[string]interface{})
-		// hostIP, ok := status["hostIP"]
-		// if !ok {
-		// 	hostIP = " "
-		// }
-		nodeName, ok := spec["nodeName"]
-		if !ok {
-			nodeName = " "
-		}
-		podIP, ok := status["podIP"]
-		if !ok {
-			podIP = "None"
-		}
-		restartCount := "0"
+		pod := newObject("Pod", item)
 		age := "0s"
-		ready := 0
-		containerStatuses, ok := status["containerStatuses"].([]interface{})
-		if ok {
-			for _, item1 := range containerStatuses {
-				cStatus := item1.(map[string]interface{})
-				if cStatus["ready"] == true {
-					ready++
-				}
-			}
-			if len(containerStatuses) > 0 {
-				firstStatus := containerStatuses[0].(map[string]interface{})
-				restartCount = strconv.FormatInt(int64((firstStatus["restartCount"].(float64))), 10)
-			}
-			startTimeStr := status["startTime"].(string)
-			age = getAge(startTimeStr)
+		ready, status, restarts := podStatus(pod)
+		if pod.has("status", "containerStatuses") {
+			age = getAge(pod.str("status", "startTime"))
+		}
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s", pod.text("metadata", "namespace"), pod.name(), ready, status, restarts, age, pod.text("status", "podIP"), pod.text("spec", "nodeName"))
+		if wide {
+			fmt.Fprintf(writer, "\t%s\t%s", pod.text("status", "nominatedNodeName"), readinessGates(pod))
 		}
-		// address := item.(map[string]interface{})["status"]["addresses"].(map[string]interface{})
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", metadata["namespace"], metadata["name"], strconv.Itoa(ready)+"/"+strconv.Itoa(len(containerStatuses)), status["phase"], restartCount, age, podIP, nodeName)
+		fmt.Fprintf(writer, "%s\n", labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintServiceList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tTYPE\tCLUSTER-IP\tEXTERNAL-IP\tPORT(S)\tAGE")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tTYPE\tCLUSTER-IP\tEXTERNAL-IP\tPORT(S)\tAGE"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		svc := item.(map[string]interface{})
-		// fmt.Println("item: ", reflect.TypeOf(node["status"]).String())
-		spec := svc["spec"].(map[string]interface{})
-		status := svc["status"].(map[string]interface{})
-		metadata := svc["metadata"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
+		svc := newObject("Service", item)
+		spec := svc.child("spec")
+		age := getAge(svc.str("metadata", "creationTimestamp"))
 		extip := "<unknown>"
 		result := sets.NewString()
-		switch spec["type"] {
-		case "ClusterIP":
-			if spec["externalIPs"] != nil && len(spec["externalIPs"].([]interface{})) > 0 {
-				extIPs := spec["externalIPs"].([]interface{})
-				for _, ip := range extIPs {
-					result.Insert(ip.(string))
-				}
-				extip = strings.Join(result.List(), ",")
-			} else {
-				extip = "<none>"
-			}
-		case "NodePort":
-			if spec["externalIPs"] != nil && len(spec["externalIPs"].([]interface{})) > 0 {
-				extIPs := spec["externalIPs"].([]interface{})
-				for _, ip := range extIPs {
-					result.Insert(ip.(string))
-				}
-				extip = strings.Join(result.List(), ",")
-			} else {
-				extip = "<none>"
-			}
+		switch spec.str("type") {
+		case "ClusterIP", "NodePort":
+			result.Insert(spec.strs("externalIPs")...)
+			extip = orNone(strings.Join(result.List(), ","))
 		case "LoadBalancer":
-			if status["loadBalancer"] != nil {
-				lb := status["loadBalancer"].(map[string]interface{})
-				if lb["ingress"] != nil && len(lb["ingress"].([]interface{})) > 0 {
-					extIPs := lb["ingress"].([]interface{})
-					for _, ing := range extIPs {
-						ingress := ing.(map[string]interface{})
-						if ingress["ip"] != nil {
-							result.Insert(ingress["ip"].(string))
-						} else if ingress["hostname"] != nil {
-							result.Insert(ingress["hostname"].(string))
-						}
-					}
-				}
-			}
-			if spec["externalIPs"] != nil && len(spec["externalIPs"].([]interface{})) > 0 {
-				extIPs := spec["externalIPs"].([]interface{})
-				for _, ip := range extIPs {
-					result.Insert(ip.(string))
+			for _, ingress := range svc.objects("status", "loadBalancer", "ingress") {
+				if ingress.has("ip") {
+					result.Insert(ingress.str("ip"))
+				} else if ingress.has("hostname") {
+					result.Insert(ingress.str("hostname"))
 				}
 			}
+			result.Insert(spec.strs("externalIPs")...)
 			if len(result) > 0 {
 				if len(result.List()) > 2 {
 					extip = strings.Join(result.List()[0:2], ",") + "..."
@@ -414,421 +297,642 @@ func prettyPrintServiceList(items []interface{}) {
 				extip = "<pending>"
 			}
 		case "ExternalName":
-			extip = spec["externalName"].(string)
-		}
-		portString := "<none>"
-		if spec["ports"] != nil && len(spec["ports"].([]interface{})) > 0 {
-			ports := spec["ports"].([]interface{})
-			portList := []string{}
-			for _, item1 := range ports {
-				port := item1.(map[string]interface{})
-				// fmt.Println(port)
-				// fmt.Println(strconv.FormatInt(int64(port["port"].(float64)), 10))
-				// fmt.Println(port["protocol"].(string))
-				portList = append(portList, strconv.FormatInt(int64(port["port"].(float64)), 10)+"/"+port["protocol"].(string))
-			}
-			portString = strings.Join(portList, ",")
+			extip = spec.text("externalName")
 		}
-		clusterIP := "<none>"
-		if spec["clusterIP"] != nil {
-			clusterIP = spec["clusterIP"].(string)
+		portList := []string{}
+		for _, port := range spec.objects("ports") {
+			portList = append(portList, strconv.FormatInt(port.num("port"), 10)+"/"+port.str("protocol"))
 		}
-		// address := item.(map[string]interface{})["status"]["addresses"].(map[string]interface{})
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", metadata["namespace"], metadata["name"], spec["type"], clusterIP, extip, portString, age)
+		portString := orNone(strings.Join(portList, ","))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s%s\n", svc.text("metadata", "namespace"), svc.name(), spec.text("type"), spec.text("clusterIP"), extip, portString, age, labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintDeploymentList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tREADY\tUP-TO-DATE\tAVAILABLE\tAGE")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tREADY\tUP-TO-DATE\tAVAILABLE\tAGE"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		deploy := item.(map[string]interface{})
-		// fmt.Println("item: ", reflect.TypeOf(node["status"]).String())
-		spec := deploy["spec"].(map[string]interface{})
-		metadata := deploy["metadata"].(map[string]interface{})
-		status := deploy["status"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
-		ready := "0"
-		if status["readyReplicas"] != nil {
-			ready = strconv.FormatInt(int64((status["readyReplicas"].(float64))), 10)
-		}
-		replica := strconv.FormatInt(int64((spec["replicas"].(float64))), 10)
-		update := "0"
-		if status["updatedReplicas"] != nil {
-			update = strconv.FormatInt(int64((status["updatedReplicas"].(float64))), 10)
-		}
-		avail := "0"
-		if status["availableReplicas"] != nil {
-			avail = strconv.FormatInt(int64((status["availableReplicas"].(float64))), 10)
-		}
-		// address := item.(map[string]interface{})["status"]["addresses"].(map[string]interface{})
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", metadata["namespace"], metadata["name"], ready+"/"+replica, update, avail, age)
+		deploy := newObject("Deployment", item)
+		age := getAge(deploy.str("metadata", "creationTimestamp"))
+		ready := strconv.FormatInt(deploy.num("status", "readyReplicas"), 10)
+		replica := deploy.count("spec", "replicas")
+		update := strconv.FormatInt(deploy.num("status", "updatedReplicas"), 10)
+		avail := strconv.FormatInt(deploy.num("status", "availableReplicas"), 10)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s%s\n", deploy.text("metadata", "namespace"), deploy.name(), ready+"/"+replica, update, avail, age, labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintReplicaSetList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tDESIRED\tCURRENT\tREADY\tAGE")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tDESIRED\tCURRENT\tREADY\tAGE"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		rs := item.(map[string]interface{})
-		// fmt.Println("item: ", reflect.TypeOf(node["status"]).String())
-		// spec := deploy["spec"].(map[string]interface{})
-		metadata := rs["metadata"].(map[string]interface{})
-		status := rs["status"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
+		rs := newObject("ReplicaSet", item)
+		age := getAge(rs.str("metadata", "creationTimestamp"))
 		// fmt.Println("name: ", metadata["name"])
-		// fmt.Println("ready: ", status["readyReplicas"])
-		replica := "0"
-		ready := "0"
-		avail := "0"
-		if status["replicas"] != nil && status["replicas"].(float64) > 0 {
-			replica = strconv.FormatInt(int64((status["replicas"].(float64))), 10)
-		}
-		if status["readyReplicas"] != nil && status["readyReplicas"].(float64) > 0 {
-			ready = strconv.FormatInt(int64((status["readyReplicas"].(float64))), 10)
-		}
-		if status["availableReplicas"] != nil && status["availableReplicas"].(float64) > 0 {
-			avail = strconv.FormatInt(int64((status["availableReplicas"].(float64))), 10)
-		}
-		// address := item.(map[string]interface{})["status"]["addresses"].(map[string]interface{})
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", metadata["namespace"], metadata["name"], replica, avail, ready, age)
+		replica := strconv.FormatInt(rs.num("status", "replicas"), 10)
+		ready := strconv.FormatInt(rs.num("status", "readyReplicas"), 10)
+		avail := strconv.FormatInt(rs.num("status", "availableReplicas"), 10)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s%s\n", rs.text("metadata", "namespace"), rs.name(), replica, avail, ready, age, labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintStatefulSetList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tREADY\tAGE")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tREADY\tAGE"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		sts := item.(map[string]interface{})
-		// fmt.Println("item: ", reflect.TypeOf(node["status"]).String())
-		spec := sts["spec"].(map[string]interface{})
-		metadata := sts["metadata"].(map[string]interface{})
-		status := sts["status"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
+		sts := newObject("StatefulSet", item)
+		age := getAge(sts.str("metadata", "creationTimestamp"))
 		// fmt.Println("name: ", metadata["name"])
-		// fmt.Println("ready: ", status["readyReplicas"])
-		replica := strconv.FormatInt(int64((spec["replicas"].(float64))), 10)
-		ready := "0"
-		if status["readyReplicas"] != nil && status["readyReplicas"].(float64) > 0 {
-			ready = strconv.FormatInt(int64((status["readyReplicas"].(float64))), 10)
-		}
-		// address := item.(map[string]interface{})["status"]["addresses"].(map[string]interface{})
-		fmt.Fprintf(writer, "%s\t%s\t%v/%v\t%s\n", metadata["namespace"], metadata["name"], ready, replica, age)
+		replica := sts.count("spec", "replicas")
+		ready := strconv.FormatInt(sts.num("status", "readyReplicas"), 10)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%v/%v\t%s%s\n", sts.text("metadata", "namespace"), sts.name(), ready, replica, age, labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintDaemonSetList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tDESIRED\tCURRENT\tREADY\tUP-TO-DATE\tAVAILABLE\tNODE SELECTOR\tAGE")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tDESIRED\tCURRENT\tREADY\tUP-TO-DATE\tAVAILABLE\tNODE SELECTOR\tAGE"+labelHeaders())
 	for _, item := range items {
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		daemon := item.(map[string]interface{})
-		// fmt.Println("item: ", reflect.TypeOf(node["status"]).String())
-		spec := daemon["spec"].(map[string]interface{})
-		template := spec["template"].(map[string]interface{})
-		templateSpec := template["spec"].(map[string]interface{})
-		metadata := daemon["metadata"].(map[string]interface{})
-		status := daemon["status"].(map[string]interface{})
-		// address := item.(map[string]interface{})["status"]["addresses"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
-		ready := "0"
-		if status["numberReady"] != nil && status["numberReady"].(float64) > 0 {
-			strconv.FormatInt(int64((status["numberReady"].(float64))), 10)
-		}
-		current := "0"
-		if status["currentNumberScheduled"] != nil && status["currentNumberScheduled"].(float64) > 0 {
-			strconv.FormatInt(int64((status["currentNumberScheduled"].(float64))), 10)
-		}
-		update := "0"
-		if status["updatedNumberScheduled"] != nil && status["updatedNumberScheduled"].(float64) > 0 {
-			strconv.FormatInt(int64((status["updatedNumberScheduled"].(float64))), 10)
-		}
-		avail := "0"
-		if status["numberAvailable"] != nil && status["numberAvailable"].(float64) > 0 {
-			avail = strconv.FormatInt(int64((status["numberAvailable"].(float64))), 10)
-		}
-		desire := "0"
-		if status["desiredNumberScheduled"] != nil && status["desiredNumberScheduled"].(float64) > 0 {
-			strconv.FormatInt(int64((status["desiredNumberScheduled"].(float64))), 10)
-		}
+		daemon := newObject("DaemonSet", item)
+		age := getAge(daemon.str("metadata", "creationTimestamp"))
+		ready := strconv.FormatInt(daemon.num("status", "numberReady"), 10)
+		current := strconv.FormatInt(daemon.num("status", "currentNumberScheduled"), 10)
+		update := strconv.FormatInt(daemon.num("status", "updatedNumberScheduled"), 10)
+		avail := strconv.FormatInt(daemon.num("status", "numberAvailable"), 10)
+		desire := strconv.FormatInt(daemon.num("status", "desiredNumberScheduled"), 10)
 		// fmt.Println("name: ", metadata["name"])
-		nodeSelectorList := []string{}
-		if _, ok := templateSpec["nodeSelector"]; ok {
-			// fmt.Println("nodeSelector: ", templateSpec["nodeSelector"])
-			nodeSelector := templateSpec["nodeSelector"].(map[string]interface{})
-			for k, v := range nodeSelector {
-				nodeSelectorList = append(nodeSelectorList, k+"="+v.(string))
-			}
-		}
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", metadata["namespace"], metadata["name"], desire, current, ready, update, avail, strings.Join(nodeSelectorList, ","), age)
+		nodeSelector := orNone(strings.Join(daemon.pairs("spec", "template", "spec", "nodeSelector"), ","))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s%s\n", daemon.text("metadata", "namespace"), daemon.name(), desire, current, ready, update, avail, nodeSelector, age, labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintEventList(items []interface{}) {
-	sort.Slice(items, func(i, j int) bool {
-		// fmt.Println("items[i].(map[string]interface{}): ", items[i].(map[string]interface{}))
-		// fmt.Println("items[j].(map[string]interface{}): ", items[j].(map[string]interface{}))
-		return getEventTime(items[i].(map[string]interface{})) > getEventTime(items[j].(map[string]interface{}))
-	})
-	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tLAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE")
+	events := []*object{}
+	eventTimes := map[*object]string{}
 	for _, item := range items {
-		event := item.(map[string]interface{})
-		metadata := event["metadata"].(map[string]interface{})
-		involvedObject := event["involvedObject"].(map[string]interface{})
-		// source := event["source"].(map[string]interface{})
-
+		event := newObject("Event", item)
+		eventTimes[event] = getEventTime(event)
+		events = append(events, event)
+	}
+	if len(sortBy) == 0 {
+		sort.SliceStable(events, func(i, j int) bool {
+			return eventTimes[events[i]] > eventTimes[events[j]]
+		})
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tLAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE"+labelHeaders())
+	for _, event := range events {
 		// fmt.Println("lastTimestampStr: ", lastTimestampStr)
 
-		age := getAge(getEventTime(event))
+		age := getAge(eventTimes[event])
 
-		message := ""
-		if event["message"] != nil {
-			message = strings.TrimSpace(event["message"].(string))
-		}
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s/%s\t%s\n", metadata["namespace"], age, event["type"], event["reason"], strings.ToLower(involvedObject["kind"].(string)), involvedObject["name"].(string), message)
+		message := strings.TrimSpace(event.str("message"))
+		fmt.Fprint(writer, sourceColumn(event.data))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s/%s\t%s%s\n", event.text("metadata", "namespace"), age, event.text("type"), event.text("reason"), strings.ToLower(event.str("involvedObject", "kind")), event.str("involvedObject", "name"), message, labelValues(event.data))
 
 		// fmt.Println("item: ", reflect.TypeOf(item).String())
-		// fmt.Println("item: ", reflect.TypeOf(node["status"]).String())
 	}
 	writer.Flush()
 }
 
 func prettyPrintPersistentVolumeList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAME\tCAPACITY\tACCESS MODES\tRECLAIM POLICY\tSTATUS\tCLAIM\tSTORAGECLASS\tREASON\tAGE\tVOLUMEMODE")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAME\tCAPACITY\tACCESS MODES\tRECLAIM POLICY\tSTATUS\tCLAIM\tSTORAGECLASS\tREASON\tAGE\tVOLUMEMODE"+labelHeaders())
 	for _, item := range items {
-		pv := item.(map[string]interface{})
-		metadata := pv["metadata"].(map[string]interface{})
-		spec := pv["spec"].(map[string]interface{})
-		status := pv["status"].(map[string]interface{})
-		capacity := spec["capacity"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
+		pv := newObject("PersistentVolume", item)
+		spec := pv.child("spec")
+		age := getAge(pv.str("metadata", "creationTimestamp"))
 		claim := ""
-		if claimRef, ok1 := spec["claimRef"].(map[string]interface{}); ok1 {
-			claim = claimRef["namespace"].(string) + "/" + claimRef["name"].(string)
+		if spec.has("claimRef") {
+			claim = spec.str("claimRef", "namespace") + "/" + spec.str("claimRef", "name")
 		}
-		accessMode := ""
-		for i, m := range spec["accessModes"].([]interface{}) {
-			if i > 0 {
-				accessMode += ","
-			}
-			accessMode += m.(string)
-		}
-		reason := ""
-		if status["reason"] != nil {
-			reason = status["reason"].(string)
-		}
-		phase := ""
-		if status["phase"] != nil {
-			phase = status["phase"].(string)
-		}
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", metadata["name"], capacity["storage"], accessMode, spec["persistentVolumeReclaimPolicy"], phase, claim, spec["storageClassName"], reason, age, spec["volumeMode"])
+		accessMode := strings.Join(spec.strs("accessModes"), ",")
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s%s\n", pv.name(), spec.text("capacity", "storage"), accessMode, spec.text("persistentVolumeReclaimPolicy"), pv.str("status", "phase"), claim, spec.text("storageClassName"), pv.str("status", "reason"), age, spec.text("volumeMode"), labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintPersistentVolumeClaimList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tSTATUS\tVOLUME\tCAPACITY\tACCESS MODES\tSTORAGECLASS\tAGE\tVOLUMEMODE")
-	for _, item := range items {
-		pvc := item.(map[string]interface{})
-		metadata := pvc["metadata"].(map[string]interface{})
-		spec := pvc["spec"].(map[string]interface{})
-		status := pvc["status"].(map[string]interface{})
-		capacity := status["capacity"].(map[string]interface{})
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
-
-		accessMode := ""
-		for i, m := range spec["accessModes"].([]interface{}) {
-			if i > 0 {
-				accessMode += ","
-			}
-			accessMode += m.(string)
-		}
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tSTATUS\tVOLUME\tCAPACITY\tACCESS MODES\tSTORAGECLASS\tAGE\tVOLUMEMODE"+labelHeaders())
+	for _, item := range items {
+		pvc := newObject("PersistentVolumeClaim", item)
+		spec := pvc.child("spec")
+		age := getAge(pvc.str("metadata", "creationTimestamp"))
 
-		phase := ""
-		if status["phase"] != nil {
-			phase = status["phase"].(string)
-		}
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", metadata["namespace"], metadata["name"], phase, spec["volumeName"], capacity["storage"], accessMode, spec["storageClassName"], age, spec["volumeMode"])
+		accessMode := strings.Join(spec.strs("accessModes"), ",")
+
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s%s\n", pvc.text("metadata", "namespace"), pvc.name(), pvc.str("status", "phase"), spec.text("volumeName"), pvc.text("status", "capacity", "storage"), accessMode, spec.text("storageClassName"), age, spec.text("volumeMode"), labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintSecretList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tTYPE\tDATA\tAGE")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tTYPE\tDATA\tAGE"+labelHeaders())
 	for _, item := range items {
-		secret := item.(map[string]interface{})
-		metadata := secret["metadata"].(map[string]interface{})
-		dataNum := 0
-		if data, ok := secret["data"].(map[string]interface{}); ok {
-			dataNum = len(data)
-		}
+		secret := newObject("Secret", item)
+		dataNum := len(secret.dict("data"))
 
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
+		age := getAge(secret.str("metadata", "creationTimestamp"))
 
-		fmt.Fprintf(writer, "%s\t%s\t%v\t%v\t%s\n", metadata["namespace"], metadata["name"], secret["type"], dataNum, age)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%v\t%v\t%s%s\n", secret.text("metadata", "namespace"), secret.name(), secret.text("type"), dataNum, age, labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintConfigMapList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tDATA\tAGE")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tDATA\tAGE"+labelHeaders())
 	for _, item := range items {
-		cm := item.(map[string]interface{})
-		metadata := cm["metadata"].(map[string]interface{})
-		dataNum := 0
-		if data, ok := cm["data"].(map[string]interface{}); ok {
-			dataNum = len(data)
-		}
+		cm := newObject("ConfigMap", item)
+		dataNum := len(cm.dict("data"))
 
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
+		age := getAge(cm.str("metadata", "creationTimestamp"))
 
-		fmt.Fprintf(writer, "%s\t%s\t%v\t%s\n", metadata["namespace"], metadata["name"], dataNum, age)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%v\t%s%s\n", cm.text("metadata", "namespace"), cm.name(), dataNum, age, labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintServiceAccountList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tSECRETS\tAGE")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tSECRETS\tAGE"+labelHeaders())
 	for _, item := range items {
-		sa := item.(map[string]interface{})
-		metadata := sa["metadata"].(map[string]interface{})
-		dataNum := 0
-		if secrets, ok := sa["secrets"].([]interface{}); ok {
-			dataNum = len(secrets)
-		}
+		sa := newObject("ServiceAccount", item)
+		dataNum := len(sa.list("secrets"))
 
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
+		age := getAge(sa.str("metadata", "creationTimestamp"))
 
-		fmt.Fprintf(writer, "%s\t%s\t%v\t%s\n", metadata["namespace"], metadata["name"], dataNum, age)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%v\t%s%s\n", sa.text("metadata", "namespace"), sa.name(), dataNum, age, labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintEndpointsList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tENDPOINTS\tAGE")
-	for _, item := range items {
-		ep := item.(map[string]interface{})
-		metadata := ep["metadata"].(map[string]interface{})
-		eps := "<none>"
-		if subsets, ok := ep["subsets"].([]interface{}); ok {
-			eps = ""
-			for _, item1 := range subsets {
-				subset := item1.(map[string]interface{})
-				adds, ok1 := subset["addresses"].([]interface{})
-				if !ok1 {
-					adds, _ = subset["notReadyAddresses"].([]interface{})
-				}
-				ports := subset["ports"].([]interface{})
-				for _, item2 := range adds {
-					add := item2.(map[string]interface{})
-					for _, item3 := range ports {
-						port := item3.(map[string]interface{})
-						eps += add["ip"].(string) + ":" + strconv.FormatInt(int64((port["port"].(float64))), 10) + " "
-					}
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tENDPOINTS\tAGE"+labelHeaders())
+	for _, item := range items {
+		ep := newObject("Endpoints", item)
+		eps := ""
+		for _, subset := range ep.objects("subsets") {
+			adds := subset.objects("addresses")
+			if !subset.has("addresses") {
+				adds = subset.objects("notReadyAddresses")
+			}
+			for _, add := range adds {
+				for _, port := range subset.objects("ports") {
+					eps += add.str("ip") + ":" + strconv.FormatInt(port.num("port"), 10) + " "
 				}
 			}
 		}
 
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
+		age := getAge(ep.str("metadata", "creationTimestamp"))
 
-		fmt.Fprintf(writer, "%s\t%s\t%v\t%s\n", metadata["namespace"], metadata["name"], strings.Replace(strings.Trim(eps, " "), " ", ",", -1), age)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%v\t%s%s\n", ep.text("metadata", "namespace"), ep.name(), orNone(strings.Replace(strings.Trim(eps, " "), " ", ",", -1)), age, labelValues(item))
 	}
 	writer.Flush()
 }
 
 func prettyPrintIngressList(items []interface{}) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAMESPACE\tNAME\tCLASS\tHOSTS\tADDRESS\tPORTS\tAGE")
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tCLASS\tHOSTS\tADDRESS\tPORTS\tAGE"+labelHeaders())
 	for _, item := range items {
-		ing := item.(map[string]interface{})
-		metadata := ing["metadata"].(map[string]interface{})
-		spec := ing["spec"].(map[string]interface{})
-		status := ing["status"].(map[string]interface{})
+		ing := newObject("Ingress", item)
 		host := "*"
 		port := "80"
 		add := ""
-		if rules, ok := spec["rules"].([]interface{}); ok {
-			for _, item := range rules {
-				rule := item.(map[string]interface{})
-				if hostStr, ok1 := rule["host"]; ok1 {
-					host = hostStr.(string)
-				}
+		for _, rule := range ing.objects("spec", "rules") {
+			if rule.has("host") {
+				host = rule.str("host")
 			}
 		}
 
-		if loadBalancer, ok := status["loadBalancer"].(map[string]interface{}); ok {
-			if ingress, ok1 := loadBalancer["ingress"].([]interface{}); ok1 {
-				for _, item := range ingress {
-					address := item.(map[string]interface{})
-					if ip, ok2 := address["ip"].(string); ok2 {
-						add = ip
-					}
-					if ports, ok2 := address["ports"].(string); ok2 {
-						port = ports
-					}
-				}
+		for _, address := range ing.objects("status", "loadBalancer", "ingress") {
+			if address.has("ip") {
+				add = address.str("ip")
+			}
+			if ports, ok := address.field("ports").(string); ok {
+				port = ports
 			}
 		}
-		creationTimeStr := metadata["creationTimestamp"].(string)
-		// fmt.Println("creationTimeStr: ", creationTimeStr)
-		age := getAge(creationTimeStr)
+		age := getAge(ing.str("metadata", "creationTimestamp"))
+
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s%s\n", ing.text("metadata", "namespace"), ing.name(), ing.text("spec", "ingressClassName"), host, add, port, age, labelValues(item))
+	}
+	writer.Flush()
+}
+
+func prettyPrintHorizontalPodAutoscalerList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tREFERENCE\tTARGETS\tMINPODS\tMAXPODS\tREPLICAS\tAGE"+labelHeaders())
+	for _, item := range items {
+		hpa := newObject("HorizontalPodAutoscaler", item)
+		spec := hpa.child("spec")
+		reference := spec.str("scaleTargetRef", "kind") + "/" + spec.str("scaleTargetRef", "name")
+		minPods := "<unset>"
+		if spec.has("minReplicas") {
+			minPods = strconv.FormatInt(spec.num("minReplicas"), 10)
+		}
+		age := getAge(hpa.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s%s\n", hpa.text("metadata", "namespace"), hpa.name(), reference, hpaMetrics(hpa), minPods, spec.num("maxReplicas"), hpa.num("status", "currentReplicas"), age, labelValues(item))
+	}
+	writer.Flush()
+}
 
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", metadata["namespace"], metadata["name"], spec["ingressClassName"], host, add, port, age)
+func prettyPrintPodDisruptionBudgetList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tMIN AVAILABLE\tMAX UNAVAILABLE\tALLOWED DISRUPTIONS\tAGE"+labelHeaders())
+	for _, item := range items {
+		pdb := newObject("PodDisruptionBudget", item)
+		minAvailable := "N/A"
+		if pdb.has("spec", "minAvailable") {
+			minAvailable = pdb.text("spec", "minAvailable")
+		}
+		maxUnavailable := "N/A"
+		if pdb.has("spec", "maxUnavailable") {
+			maxUnavailable = pdb.text("spec", "maxUnavailable")
+		}
+		age := getAge(pdb.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\t%s%s\n", pdb.text("metadata", "namespace"), pdb.name(), minAvailable, maxUnavailable, pdb.num("status", "disruptionsAllowed"), age, labelValues(item))
 	}
 	writer.Flush()
 }
 
-func getEventTime(event map[string]interface{}) string {
-	if series, ok := event["series"].(map[string]interface{}); ok {
-		return series["lastObservedTime"].(string)
+func prettyPrintResourceQuotaList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tAGE\tREQUEST\tLIMIT"+labelHeaders())
+	for _, item := range items {
+		quota := newObject("ResourceQuota", item)
+		resources := []string{}
+		for resource := range quota.dict("status", "hard") {
+			resources = append(resources, resource)
+		}
+		sort.Strings(resources)
+		requests := []string{}
+		limits := []string{}
+		for _, resource := range resources {
+			used := "0"
+			if quota.has("status", "used", resource) {
+				used = quota.text("status", "used", resource)
+			}
+			usage := fmt.Sprintf("%s: %s/%s", resource, used, quota.text("status", "hard", resource))
+			// resources such as limits.cpu go to the LIMIT column
+			if strings.HasPrefix(resource, "limits.") {
+				limits = append(limits, usage)
+			} else {
+				requests = append(requests, usage)
+			}
+		}
+		age := getAge(quota.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s%s\n", quota.text("metadata", "namespace"), quota.name(), age, strings.Join(requests, ", "), strings.Join(limits, ", "), labelValues(item))
 	}
-	if lastTimestamp, ok := event["lastTimestamp"].(string); ok {
+	writer.Flush()
+}
+
+func prettyPrintLimitRangeList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tCREATED AT"+labelHeaders())
+	for _, item := range items {
+		limits := newObject("LimitRange", item)
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s%s\n", limits.text("metadata", "namespace"), limits.name(), limits.text("metadata", "creationTimestamp"), labelValues(item))
+	}
+	writer.Flush()
+}
+
+func prettyPrintPriorityClassList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAME\tVALUE\tGLOBAL-DEFAULT\tAGE\tPREEMPTIONPOLICY"+labelHeaders())
+	for _, item := range items {
+		pc := newObject("PriorityClass", item)
+		age := getAge(pc.str("metadata", "creationTimestamp"))
+		// the API server defaults preemptionPolicy
+		preemptionPolicy := pc.str("preemptionPolicy")
+		if len(preemptionPolicy) == 0 {
+			preemptionPolicy = "PreemptLowerPriority"
+		}
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%d\t%t\t%s\t%s%s\n", pc.name(), pc.num("value"), pc.flag("globalDefault"), age, preemptionPolicy, labelValues(item))
+	}
+	writer.Flush()
+}
+
+func prettyPrintNetworkPolicyList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tPOD-SELECTOR\tAGE"+labelHeaders())
+	for _, item := range items {
+		netpol := newObject("NetworkPolicy", item)
+		age := getAge(netpol.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s%s\n", netpol.text("metadata", "namespace"), netpol.name(), formatSelector(netpol, "spec", "podSelector"), age, labelValues(item))
+	}
+	writer.Flush()
+}
+
+func prettyPrintEndpointSliceList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tADDRESSTYPE\tPORTS\tENDPOINTS\tAGE"+labelHeaders())
+	for _, item := range items {
+		slice := newObject("EndpointSlice", item)
+		ports := []string{}
+		for _, port := range slice.objects("ports") {
+			switch {
+			case port.has("port"):
+				ports = append(ports, strconv.FormatInt(port.num("port"), 10))
+			case port.has("name"):
+				ports = append(ports, port.str("name"))
+			default:
+				ports = append(ports, "*")
+			}
+		}
+		addresses := []string{}
+		for _, endpoint := range slice.objects("endpoints") {
+			addresses = append(addresses, endpoint.strs("addresses")...)
+		}
+		age := getAge(slice.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s%s\n", slice.text("metadata", "namespace"), slice.name(), slice.text("addressType"), firstOf(ports, 3), firstOf(addresses, 3), age, labelValues(item))
+	}
+	writer.Flush()
+}
+
+func prettyPrintIngressClassList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAME\tCONTROLLER\tPARAMETERS\tAGE"+labelHeaders())
+	for _, item := range items {
+		class := newObject("IngressClass", item)
+		parameters := none
+		if class.has("spec", "parameters") {
+			params := class.child("spec", "parameters")
+			parameters = params.str("kind")
+			if params.has("apiGroup") {
+				parameters += "." + params.str("apiGroup")
+			}
+			parameters += "/" + params.str("name")
+		}
+		age := getAge(class.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s%s\n", class.name(), class.text("spec", "controller"), parameters, age, labelValues(item))
+	}
+	writer.Flush()
+}
+
+func prettyPrintCSIDriverList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAME\tATTACHREQUIRED\tPODINFOONMOUNT\tSTORAGECAPACITY\tTOKENREQUESTS\tREQUIRESREPUBLISH\tMODES\tAGE"+labelHeaders())
+	for _, item := range items {
+		driver := newObject("CSIDriver", item)
+		spec := driver.child("spec")
+		// attachRequired defaults to true
+		attachRequired := !spec.has("attachRequired") || spec.flag("attachRequired")
+		audiences := []string{}
+		for _, request := range spec.objects("tokenRequests") {
+			audiences = append(audiences, request.str("audience"))
+		}
+		tokenRequests := "<unset>"
+		if len(audiences) > 0 {
+			tokenRequests = strings.Join(audiences, ",")
+		}
+		age := getAge(driver.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%t\t%t\t%t\t%s\t%t\t%s\t%s%s\n", driver.name(), attachRequired, spec.flag("podInfoOnMount"), spec.flag("storageCapacity"), tokenRequests, spec.flag("requiresRepublish"), orNone(strings.Join(spec.strs("volumeLifecycleModes"), ",")), age, labelValues(item))
+	}
+	writer.Flush()
+}
+
+func prettyPrintCSINodeList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	header := "NAME\tDRIVERS\tAGE"
+	if wide {
+		header += "\tDRIVER NAMES"
+	}
+	fmt.Fprintln(writer, header+labelHeaders())
+	for _, item := range items {
+		csiNode := newObject("CSINode", item)
+		drivers := []string{}
+		for _, driver := range csiNode.objects("spec", "drivers") {
+			drivers = append(drivers, driver.str("name"))
+		}
+		age := getAge(csiNode.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%d\t%s", csiNode.name(), len(drivers), age)
+		if wide {
+			fmt.Fprintf(writer, "\t%s", orNone(strings.Join(drivers, ",")))
+		}
+		fmt.Fprintf(writer, "%s\n", labelValues(item))
+	}
+	writer.Flush()
+}
+
+func prettyPrintVolumeAttachmentList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	header := "NAME\tATTACHER\tPV\tNODE\tATTACHED\tAGE"
+	if wide {
+		header += "\tATTACH ERROR\tDETACH ERROR"
+	}
+	fmt.Fprintln(writer, header+labelHeaders())
+	for _, item := range items {
+		attachment := newObject("VolumeAttachment", item)
+		spec := attachment.child("spec")
+		age := getAge(attachment.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%t\t%s", attachment.name(), spec.text("attacher"), spec.str("source", "persistentVolumeName"), spec.text("nodeName"), attachment.flag("status", "attached"), age)
+		if wide {
+			fmt.Fprintf(writer, "\t%s\t%s", attachment.text("status", "attachError", "message"), attachment.text("status", "detachError", "message"))
+		}
+		fmt.Fprintf(writer, "%s\n", labelValues(item))
+	}
+	writer.Flush()
+}
+
+func prettyPrintValidatingWebhookConfigurationList(items []interface{}) {
+	printWebhookConfigurationList("ValidatingWebhookConfiguration", items)
+}
+
+func prettyPrintMutatingWebhookConfigurationList(items []interface{}) {
+	printWebhookConfigurationList("MutatingWebhookConfiguration", items)
+}
+
+// printWebhookConfigurationList prints webhook configurations of kind with
+// the failure policies of their webhooks and the services they call.
+func printWebhookConfigurationList(kind string, items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAME\tWEBHOOKS\tFAILURE-POLICY\tSERVICE\tAGE"+labelHeaders())
+	for _, item := range items {
+		config := newObject(kind, item)
+		policies := sets.NewString()
+		services := []string{}
+		for _, webhook := range config.objects("webhooks") {
+			// failurePolicy defaults to Fail in admissionregistration/v1
+			policy := webhook.str("failurePolicy")
+			if len(policy) == 0 {
+				policy = "Fail"
+			}
+			policies.Insert(policy)
+			services = append(services, webhookService(webhook.child("clientConfig")))
+		}
+		service := none
+		if len(services) > 0 {
+			service = firstOfUnique(services, 3)
+		}
+		age := getAge(config.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%d\t%s\t%s\t%s%s\n", config.name(), len(services), orNone(strings.Join(policies.List(), ",")), service, age, labelValues(item))
+	}
+	writer.Flush()
+}
+
+// webhookService returns where a webhook is called, the namespace/name:port
+// of its service or its URL.
+func webhookService(clientConfig *object) string {
+	if !clientConfig.has("service") {
+		return clientConfig.text("url")
+	}
+	service := clientConfig.str("service", "namespace") + "/" + clientConfig.str("service", "name")
+	if clientConfig.has("service", "port") {
+		service += ":" + strconv.FormatInt(clientConfig.num("service", "port"), 10)
+	}
+	return service + clientConfig.str("service", "path")
+}
+
+func prettyPrintAPIServiceList(items []interface{}) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(writer, sourceHeader())
+	fmt.Fprintln(writer, "NAME\tSERVICE\tAVAILABLE\tAGE"+labelHeaders())
+	for _, item := range items {
+		apiService := newObject("APIService", item)
+		service := "Local"
+		if apiService.has("spec", "service") {
+			service = apiService.str("spec", "service", "namespace") + "/" + apiService.str("spec", "service", "name")
+		}
+		available := "Unknown"
+		for _, condition := range apiService.objects("status", "conditions") {
+			if condition.str("type") != "Available" {
+				continue
+			}
+			switch {
+			case condition.str("status") == "True":
+				available = "True"
+			case len(condition.str("reason")) > 0:
+				available = fmt.Sprintf("%s (%s)", condition.str("status"), condition.str("reason"))
+			default:
+				available = condition.str("status")
+			}
+		}
+		age := getAge(apiService.str("metadata", "creationTimestamp"))
+		fmt.Fprint(writer, sourceColumn(item))
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s%s\n", apiService.name(), service, available, age, labelValues(item))
+	}
+	writer.Flush()
+}
+
+// formatSelector returns a label selector field, with its match expressions,
+// as kubectl prints it, e.g. app=web,tier in (api,web). An empty selector,
+// which selects everything, is <none>.
+func formatSelector(o *object, fields ...string) string {
+	selector := &metav1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.dict(fields...), selector); err != nil {
+		o.problem(fields, "is not a label selector: %v", err)
+		return none
+	}
+	return metav1.FormatLabelSelector(selector)
+}
+
+// firstOf returns the first max of values, joined with commas, saying how
+// many more there are, or <unset> if there are none.
+func firstOf(values []string, max int) string {
+	if len(values) == 0 {
+		return "<unset>"
+	}
+	if len(values) > max {
+		return fmt.Sprintf("%s + %d more...", strings.Join(values[:max], ","), len(values)-max)
+	}
+	return strings.Join(values, ",")
+}
+
+// firstOfUnique is firstOf without the repeated values, keeping the order
+// of the first ones.
+func firstOfUnique(values []string, max int) string {
+	seen := sets.NewString()
+	unique := []string{}
+	for _, value := range values {
+		if !seen.Has(value) {
+			seen.Insert(value)
+			unique = append(unique, value)
+		}
+	}
+	return firstOf(unique, max)
+}
+
+func getEventTime(event *object) string {
+	if event.has("series") {
+		return event.str("series", "lastObservedTime")
+	}
+	if lastTimestamp := event.str("lastTimestamp"); len(lastTimestamp) > 0 {
 		return lastTimestamp
 	}
-	return event["eventTime"].(string)
+	return event.str("eventTime")
 }
 
+// getAge returns the time from creationTimeStr to when the dump was taken.
 func getAge(creationTimeStr string) string {
-	creationTime, err := time.Parse("2006-01-02T15:04:05Z", creationTimeStr)
+	if len(creationTimeStr) == 0 {
+		return none
+	}
+	creationTime, err := time.Parse(time.RFC3339, creationTimeStr)
 	// fmt.Println("creationTimeStr: ", creationTimeStr)
 	age := "0s"
 	if err == nil {
-		ageTime := time.Now().Sub(creationTime)
+		ageTime := referenceTime().Sub(creationTime)
 		// fmt.Println("ageTime: ", ageTime)
 		return getDisplayTime(ageTime)
 	} else {
@@ -838,8 +942,11 @@ func getAge(creationTimeStr string) string {
 }
 
 func getDuration(startTimeStr string, completionTimeStr string) string {
-	startTime, err := time.Parse("2006-01-02T15:04:05Z", startTimeStr)
-	completionTime, err1 := time.Parse("2006-01-02T15:04:05Z", completionTimeStr)
+	if len(startTimeStr) == 0 || len(completionTimeStr) == 0 {
+		return none
+	}
+	startTime, err := time.Parse(time.RFC3339, startTimeStr)
+	completionTime, err1 := time.Parse(time.RFC3339, completionTimeStr)
 	// fmt.Println("creationTimeStr: ", startTimeStr)
 	// fmt.Println("completionTimeStr: ", completionTimeStr)
 	duration := "0s"
@@ -857,9 +964,9 @@ func getDisplayTime(durationTime time.Duration) string {
 	// fmt.Println("durationTime: ", durationTime.Hours())
 	// fmt.Println("durationTime: ", durationTime.Minutes())
 	// fmt.Println("durationTime: ", durationTime.Seconds())
-	if durationTime.Hours() > 24 {
+	if durationTime.Hours() >= 24 {
 		duration = strconv.FormatInt(int64(durationTime.Hours()/24), 10) + "d"
-	} else if durationTime.Hours() < 24 && durationTime.Hours() > 1 {
+	} else if durationTime.Hours() > 1 {
 		duration = strconv.FormatInt(int64(durationTime.Hours()), 10) + "h"
 	} else if durationTime.Hours() <= 1 && durationTime.Minutes() >= 2 {
 		duration = strconv.FormatInt(int64(durationTime.Minutes()), 10) + "m"
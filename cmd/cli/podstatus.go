@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// podStatus returns the READY, STATUS and RESTARTS columns of a pod as
+// kubectl get computes them: the reason of a waiting or terminated container
+// takes the place of the phase, failing init containers show as Init:...,
+// and restarts are summed over the containers with the time of the last one.
+func podStatus(pod *object) (string, string, string) {
+	restarts := int64(0)
+	restartableInitRestarts := int64(0)
+	totalContainers := len(pod.list("spec", "containers"))
+	readyContainers := 0
+	lastRestart := time.Time{}
+	lastRestartableInitRestart := time.Time{}
+
+	reason := pod.str("status", "phase")
+	if len(pod.str("status", "reason")) > 0 {
+		reason = pod.str("status", "reason")
+	}
+	for _, condition := range pod.objects("status", "conditions") {
+		if condition.str("type") == "PodScheduled" && condition.str("reason") == "SchedulingGated" {
+			reason = "SchedulingGated"
+		}
+	}
+
+	restartable := map[string]bool{}
+	initContainers := pod.objects("spec", "initContainers")
+	for _, container := range initContainers {
+		if container.str("restartPolicy") == "Always" {
+			restartable[container.str("name")] = true
+			totalContainers++
+		}
+	}
+
+	initializing := false
+	for i, container := range pod.objects("status", "initContainerStatuses") {
+		restarts += container.num("restartCount")
+		finished := terminatedAt(container)
+		lastRestart = laterOf(lastRestart, finished)
+		if restartable[container.str("name")] {
+			restartableInitRestarts += container.num("restartCount")
+			lastRestartableInitRestart = laterOf(lastRestartableInitRestart, finished)
+		}
+		switch {
+		case container.has("state", "terminated") && container.num("state", "terminated", "exitCode") == 0:
+			continue
+		case restartable[container.str("name")] && container.flag("started"):
+			if container.flag("ready") {
+				readyContainers++
+			}
+			continue
+		case container.has("state", "terminated"):
+			// initialization is failed
+			terminated := container.child("state", "terminated")
+			if len(terminated.str("reason")) > 0 {
+				reason = "Init:" + terminated.str("reason")
+			} else if terminated.num("signal") != 0 {
+				reason = fmt.Sprintf("Init:Signal:%d", terminated.num("signal"))
+			} else {
+				reason = fmt.Sprintf("Init:ExitCode:%d", terminated.num("exitCode"))
+			}
+		case len(container.str("state", "waiting", "reason")) > 0 && container.str("state", "waiting", "reason") != "PodInitializing":
+			reason = "Init:" + container.str("state", "waiting", "reason")
+		default:
+			reason = fmt.Sprintf("Init:%d/%d", i, len(initContainers))
+		}
+		initializing = true
+		break
+	}
+
+	if !initializing || hasCondition(pod, "Initialized") {
+		restarts = restartableInitRestarts
+		lastRestart = lastRestartableInitRestart
+		hasRunning := false
+		containerStatuses := pod.objects("status", "containerStatuses")
+		for i := len(containerStatuses) - 1; i >= 0; i-- {
+			container := containerStatuses[i]
+			restarts += container.num("restartCount")
+			lastRestart = laterOf(lastRestart, terminatedAt(container))
+			terminated := container.child("state", "terminated")
+			switch {
+			case len(container.str("state", "waiting", "reason")) > 0:
+				reason = container.str("state", "waiting", "reason")
+			case len(terminated.str("reason")) > 0:
+				reason = terminated.str("reason")
+			case container.has("state", "terminated") && terminated.num("signal") != 0:
+				reason = fmt.Sprintf("Signal:%d", terminated.num("signal"))
+			case container.has("state", "terminated"):
+				reason = fmt.Sprintf("ExitCode:%d", terminated.num("exitCode"))
+			case container.flag("ready") && container.has("state", "running"):
+				hasRunning = true
+				readyContainers++
+			}
+		}
+		// a pod with a container still running is not completed
+		if reason == "Completed" && hasRunning {
+			if hasCondition(pod, "Ready") {
+				reason = "Running"
+			} else {
+				reason = "NotReady"
+			}
+		}
+	}
+
+	if pod.has("metadata", "deletionTimestamp") && pod.str("status", "reason") == "NodeLost" {
+		reason = "Unknown"
+	} else if pod.has("metadata", "deletionTimestamp") {
+		reason = "Terminating"
+	}
+
+	restartStr := strconv.FormatInt(restarts, 10)
+	if restarts != 0 && !lastRestart.IsZero() {
+		restartStr = fmt.Sprintf("%d (%s ago)", restarts, getDisplayTime(referenceTime().Sub(lastRestart)))
+	}
+	return fmt.Sprintf("%d/%d", readyContainers, totalContainers), orNone(reason), restartStr
+}
+
+// readinessGates returns the READINESS GATES column of a pod, the number of
+// its readiness gates whose condition is true.
+func readinessGates(pod *object) string {
+	gates := pod.objects("spec", "readinessGates")
+	if len(gates) == 0 {
+		return none
+	}
+	trueConditions := 0
+	for _, gate := range gates {
+		if hasCondition(pod, gate.str("conditionType")) {
+			trueConditions++
+		}
+	}
+	return fmt.Sprintf("%d/%d", trueConditions, len(gates))
+}
+
+// hasCondition reports whether the condition of type conditionType of a pod
+// is true.
+func hasCondition(pod *object, conditionType string) bool {
+	for _, condition := range pod.objects("status", "conditions") {
+		if condition.str("type") == conditionType {
+			return condition.str("status") == "True"
+		}
+	}
+	return false
+}
+
+// terminatedAt returns when the previous run of a container ended, or the
+// zero time.
+func terminatedAt(container *object) time.Time {
+	finished, err := time.Parse(time.RFC3339, container.str("lastState", "terminated", "finishedAt"))
+	if err != nil {
+		return time.Time{}
+	}
+	return finished
+}
+
+func laterOf(a time.Time, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// testObject returns an object of kind decoded from YAML.
+func testObject(t *testing.T, kind string, data string) *object {
+	t.Helper()
+	item := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(data), &item); err != nil {
+		t.Fatalf("bad test object: %v", err)
+	}
+	return newObject(kind, item)
+}
+
+// atCaptureTime sets the capture time ages are computed against for the
+// duration of a test.
+func atCaptureTime(t *testing.T, capture time.Time) {
+	saved := captureTime
+	captureTime = capture
+	t.Cleanup(func() { captureTime = saved })
+}
+
+// The expected columns are those kubectl get pods prints for the same pods.
+func TestPodStatus(t *testing.T) {
+	atCaptureTime(t, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name     string
+		pod      string
+		ready    string
+		status   string
+		restarts string
+	}{
+		{
+			name: "running",
+			pod: `
+spec: {containers: [{name: a}]}
+status:
+  phase: Running
+  containerStatuses: [{name: a, ready: true, restartCount: 0, state: {running: {}}}]`,
+			ready: "1/1", status: "Running", restarts: "0",
+		},
+		{
+			name: "pending without statuses",
+			pod: `
+spec: {containers: [{name: a}, {name: b}]}
+status: {phase: Pending}`,
+			ready: "0/2", status: "Pending", restarts: "0",
+		},
+		{
+			name: "evicted",
+			pod: `
+spec: {containers: [{name: a}]}
+status: {phase: Failed, reason: Evicted}`,
+			ready: "0/1", status: "Evicted", restarts: "0",
+		},
+		{
+			name: "scheduling gated",
+			pod: `
+spec: {containers: [{name: a}]}
+status:
+  phase: Pending
+  conditions: [{type: PodScheduled, status: "False", reason: SchedulingGated}]`,
+			ready: "0/1", status: "SchedulingGated", restarts: "0",
+		},
+		{
+			name: "waiting for the second init container",
+			pod: `
+spec: {initContainers: [{name: i1}, {name: i2}], containers: [{name: a}]}
+status:
+  phase: Pending
+  initContainerStatuses:
+  - {name: i1, restartCount: 0, state: {terminated: {exitCode: 0, reason: Completed}}}
+  - {name: i2, restartCount: 0, state: {running: {}}}
+  containerStatuses: [{name: a, restartCount: 0, state: {waiting: {reason: PodInitializing}}}]`,
+			ready: "0/1", status: "Init:1/2", restarts: "0",
+		},
+		{
+			name: "init container crash looping",
+			pod: `
+spec: {initContainers: [{name: i1}], containers: [{name: a}]}
+status:
+  phase: Pending
+  initContainerStatuses:
+  - name: i1
+    restartCount: 3
+    state: {waiting: {reason: CrashLoopBackOff}}
+    lastState: {terminated: {exitCode: 1, finishedAt: "2024-05-01T11:55:00Z"}}`,
+			ready: "0/1", status: "Init:CrashLoopBackOff", restarts: "3 (5m ago)",
+		},
+		{
+			name: "init container failed",
+			pod: `
+spec: {initContainers: [{name: i1}], containers: [{name: a}]}
+status:
+  phase: Failed
+  initContainerStatuses: [{name: i1, restartCount: 0, state: {terminated: {exitCode: 1, reason: Error}}}]`,
+			ready: "0/1", status: "Init:Error", restarts: "0",
+		},
+		{
+			name: "init container exited without a reason",
+			pod: `
+spec: {initContainers: [{name: i1}], containers: [{name: a}]}
+status:
+  phase: Failed
+  initContainerStatuses: [{name: i1, restartCount: 0, state: {terminated: {exitCode: 2}}}]`,
+			ready: "0/1", status: "Init:ExitCode:2", restarts: "0",
+		},
+		{
+			name: "init container killed by a signal",
+			pod: `
+spec: {initContainers: [{name: i1}], containers: [{name: a}]}
+status:
+  phase: Failed
+  initContainerStatuses: [{name: i1, restartCount: 0, state: {terminated: {exitCode: 137, signal: 9}}}]`,
+			ready: "0/1", status: "Init:Signal:9", restarts: "0",
+		},
+		{
+			name: "sidecar started",
+			pod: `
+spec: {initContainers: [{name: proxy, restartPolicy: Always}], containers: [{name: a}]}
+status:
+  phase: Running
+  conditions: [{type: Initialized, status: "True"}, {type: Ready, status: "True"}]
+  initContainerStatuses: [{name: proxy, started: true, ready: true, restartCount: 1, state: {running: {}}, lastState: {terminated: {exitCode: 1, finishedAt: "2024-05-01T09:00:00Z"}}}]
+  containerStatuses: [{name: a, ready: true, restartCount: 0, state: {running: {}}}]`,
+			ready: "2/2", status: "Running", restarts: "1 (3h ago)",
+		},
+		{
+			name: "crash looping with restarts at capture time",
+			pod: `
+spec: {containers: [{name: a}]}
+status:
+  phase: Running
+  containerStatuses:
+  - name: a
+    ready: false
+    restartCount: 5
+    state: {waiting: {reason: CrashLoopBackOff}}
+    lastState: {terminated: {exitCode: 1, finishedAt: "2024-05-01T11:55:00Z"}}`,
+			ready: "0/1", status: "CrashLoopBackOff", restarts: "5 (5m ago)",
+		},
+		{
+			name: "restarts summed over containers",
+			pod: `
+spec: {containers: [{name: a}, {name: b}]}
+status:
+  phase: Running
+  containerStatuses:
+  - {name: a, ready: true, restartCount: 2, state: {running: {}}, lastState: {terminated: {exitCode: 1, finishedAt: "2024-04-28T12:00:00Z"}}}
+  - {name: b, ready: true, restartCount: 1, state: {running: {}}, lastState: {terminated: {exitCode: 1, finishedAt: "2024-05-01T11:00:00Z"}}}`,
+			ready: "2/2", status: "Running", restarts: "3 (60m ago)",
+		},
+		{
+			name: "completed container next to a ready one",
+			pod: `
+spec: {containers: [{name: job}, {name: a}]}
+status:
+  phase: Running
+  conditions: [{type: Ready, status: "True"}]
+  containerStatuses:
+  - {name: job, ready: false, restartCount: 0, state: {terminated: {exitCode: 0, reason: Completed}}}
+  - {name: a, ready: true, restartCount: 0, state: {running: {}}}`,
+			ready: "1/2", status: "Running", restarts: "0",
+		},
+		{
+			name: "completed container next to one in a pod not ready",
+			pod: `
+spec: {containers: [{name: job}, {name: a}]}
+status:
+  phase: Running
+  conditions: [{type: Ready, status: "False"}]
+  containerStatuses:
+  - {name: job, ready: false, restartCount: 0, state: {terminated: {exitCode: 0, reason: Completed}}}
+  - {name: a, ready: true, restartCount: 0, state: {running: {}}}`,
+			ready: "1/2", status: "NotReady", restarts: "0",
+		},
+		{
+			name: "completed",
+			pod: `
+spec: {containers: [{name: a}]}
+status:
+  phase: Succeeded
+  containerStatuses: [{name: a, ready: false, restartCount: 0, state: {terminated: {exitCode: 0, reason: Completed}}}]`,
+			ready: "0/1", status: "Completed", restarts: "0",
+		},
+		{
+			name: "terminating",
+			pod: `
+metadata: {deletionTimestamp: "2024-05-01T11:59:00Z"}
+spec: {containers: [{name: a}]}
+status:
+  phase: Running
+  containerStatuses: [{name: a, ready: true, restartCount: 0, state: {running: {}}}]`,
+			ready: "1/1", status: "Terminating", restarts: "0",
+		},
+		{
+			name: "node lost",
+			pod: `
+metadata: {deletionTimestamp: "2024-05-01T11:59:00Z"}
+spec: {containers: [{name: a}]}
+status:
+  phase: Running
+  reason: NodeLost
+  containerStatuses: [{name: a, ready: true, restartCount: 0, state: {running: {}}}]`,
+			ready: "1/1", status: "Unknown", restarts: "0",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ready, status, restarts := podStatus(testObject(t, "Pod", test.pod))
+			if ready != test.ready || status != test.status || restarts != test.restarts {
+				t.Errorf("podStatus = %s %s %s, want %s %s %s", ready, status, restarts, test.ready, test.status, test.restarts)
+			}
+		})
+	}
+}
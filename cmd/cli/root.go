@@ -1,95 +1,46 @@
 package cli
 
 import (
-	"bufio"
-	"errors"
 	"fmt"
-	"io"
 	"log"
 	"os"
-	"strings"
-	"text/tabwriter"
 
 	"github.com/shundezhang/kubedmp/cmd/build"
+	"github.com/shundezhang/kubedmp/pkg/dump"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
-type ProcessBuffer func(string)
-
 var (
 	dumpFileFlag = "dumpfile"
 	dumpDirFlag  = "dumpdir"
 	getVersion   bool
 	getTypes     bool
 
-	dumpFile   string
-	dumpDir    string
-	dumpFormat = "json"
+	dumpFiles []string
+	dumpDirs  []string
+	// dumpData is the dump opened by openDump.
+	dumpData *dump.Dump
 
 	resType       string
 	resNamespace  string
 	resName       string
 	resContainer  string
 	allNamespaces bool
-	resKind       string
-
-	SupportTypes = map[string][]string{
-		"Node":                  {"no", "node", "nodes"},
-		"Pod":                   {"po", "pod", "pods"},
-		"Service":               {"svc", "service", "services"},
-		"Deployment":            {"deploy", "deployment", "deployments"},
-		"DaemonSet":             {"ds", "daemonset", "daemonsets"},
-		"ReplicaSet":            {"rs", "replicaset", "replicasets"},
-		"Event":                 {"event", "events"},
-		"PersistentVolume":      {"pv", "persistentvolumes"},
-		"PersistentVolumeClaim": {"pvc", "persistentvolumeclaim", "persistentvolumeclaims"},
-		"StatefulSet":           {"sts", "statefulset", "statefulsets"},
-		"Secret":                {"secrets", "secret"},
-		"ConfigMap":             {"cm", "configmap", "configmaps"},
-		"ServiceAccount":        {"sa", "serviceaccount", "serviceaccounts"},
-		"Ingress":               {"ing", "ingress", "ingresses"},
-		"StorageClass":          {"sc", "storageclass", "storageclasses"},
-		"ClusterRole":           {"clusterrole", "clusterroles"},
-		"ClusterRoleBinding":    {"clusterrolebinding", "clusterrolebindings"},
-		"Endpoints":             {"ep", "endpoint", "endpoints"},
-		"Job":                   {"job", "jobs"},
-		"CronJob":               {"cj", "cronjob", "cronjobs"},
-		"Role":                  {"role", "roles"},
-		"RoleBinding":           {"rolebinding", "rolebindings"},
-	}
+	// resource is the type of resType.
+	resource *resourceType
 
-	DumpFileNames = map[string]string{
-		"Node":                  "nodes",
-		"Pod":                   "pods",
-		"Service":               "services",
-		"Deployment":            "deployments",
-		"DaemonSet":             "daemonsets",
-		"ReplicaSet":            "replicasets",
-		"Event":                 "events",
-		"PersistentVolume":      "pv",
-		"PersistentVolumeClaim": "pvc",
-		"StatefulSet":           "statefulsets",
-		"Secret":                "secrets",
-		"ConfigMap":             "configmaps",
-		"ServiceAccount":        "serviceaccounts",
-		"Ingress":               "ingresses",
-		"StorageClass":          "sc",
-		"ClusterRole":           "clusterroles",
-		"ClusterRoleBinding":    "clusterrolebindings",
-		"Endpoints":             "endpoints",
-		"Job":                   "jobs",
-		"CronJob":               "cronjobs",
-		"Role":                  "roles",
-		"RoleBinding":           "rolebindings",
-	}
-
-	UnnamespacedTypes = []string{"Node", "PersistentVolume", "StorageClass", "ClusterRole", "ClusterRoleBinding"}
+	labelSelector string
+	fieldSelector string
 )
 
 const (
 	ns = "namespace"
 	an = "all-namespaces"
+
+	defaultDumpFile = "./cluster-info.dump"
 )
 
 var rootCmd = &cobra.Command{
@@ -105,12 +56,7 @@ var rootCmd = &cobra.Command{
 			os.Exit(0)
 		}
 		if getTypes {
-			writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-			fmt.Println("Supported types: ")
-			for name, types := range SupportTypes {
-				fmt.Fprintf(writer, "  %s:\t%s\n", name, strings.Join(types, " "))
-			}
-			writer.Flush()
+			printTypes()
 			os.Exit(0)
 		}
 		cmd.Help()
@@ -131,37 +77,51 @@ func init() {
 	rootCmd.Flags().BoolVarP(&getTypes, "types", "t", false, "show supported resource types")
 }
 
-func initConfig() {
-	viper.AutomaticEnv()
+// addDumpFlags adds the -f and -d flags to cmd. Both can be repeated to
+// merge several dumps into one view.
+func addDumpFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringArrayVarP(&dumpFiles, dumpFileFlag, "f", nil, "Path to dump file or archive, or - to read it from stdin (default "+defaultDumpFile+"); can be repeated")
+	cmd.PersistentFlags().StringArrayVarP(&dumpDirs, dumpDirFlag, "d", nil, "Path to dump directory or archive; can be repeated")
 }
 
-func hasType(resType string) bool {
-	for _, types := range SupportTypes {
-		if contains(types, resType) {
-			return true
-		}
+// openDump opens the dumps given with -f and -d as one dumpData.
+func openDump() {
+	locations := append(append([]string{}, dumpFiles...), dumpDirs...)
+	if len(locations) == 0 {
+		locations = []string{defaultDumpFile}
 	}
+	var err error
+	dumpData, err = dump.Open(locations...)
+	if err != nil {
+		log.Fatalf("Error to open dump: %v", err.Error())
+	}
+}
 
-	log.Fatalf("%s is not a supported resource type.\n", resType)
-	return false
-
+// addSelectorFlags adds the -l and --field-selector flags to cmd.
+func addSelectorFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Selector (label query) to filter on, supports '=', '==', '!=', 'in', 'notin' and 'exists', e.g. -l key1=value1,key2 in (value2,value3)")
+	cmd.Flags().StringVar(&fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==' and '!=' on any field, e.g. --field-selector status.phase=Failed,spec.nodeName=node-3")
 }
 
-func inType(resType string, key string) bool {
-	if contains(SupportTypes[key], resType) {
-		return true
+// selectors parses the selectors given with -l and --field-selector.
+func selectors() (labels.Selector, fields.Selector) {
+	labelSel, err := labels.Parse(labelSelector)
+	if err != nil {
+		log.Fatalf("Error parsing selector %s: %v", labelSelector, err.Error())
 	}
-	return false
+	fieldSel, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		log.Fatalf("Error parsing field selector %s: %v", fieldSelector, err.Error())
+	}
+	return labelSel, fieldSel
 }
 
-func getKind(resType string) (string, error) {
-	for kind, types := range SupportTypes {
-		if contains(types, resType) {
-			return kind, nil
-		}
-	}
+func hasSelector() bool {
+	return len(labelSelector) > 0 || len(fieldSelector) > 0
+}
 
-	return "", errors.New("resource type not supported")
+func initConfig() {
+	viper.AutomaticEnv()
 }
 
 func contains(s []string, e string) bool {
@@ -172,47 +132,3 @@ func contains(s []string, e string) bool {
 	}
 	return false
 }
-
-func readFile(filePath string, pb ProcessBuffer) {
-	// log.Print(filePath)
-	_, error := os.Stat(filePath)
-
-	// check if error is "file not exists"
-	if os.IsNotExist(error) {
-		return
-	}
-	f, err := os.Open(filePath)
-	if err != nil {
-		log.Fatalf("Error to read [file=%v]: %v", filePath, err.Error())
-	}
-	var buffer string
-	var inject bool
-
-	reader := bufio.NewReader(f)
-	defer f.Close()
-
-	for {
-		line, err := reader.ReadString('\n')
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			log.Fatalf("Error while reading FD %v: %v", f.Fd(), err)
-			break
-		}
-		line = strings.TrimSuffix(line, "\n")
-		// log.Println("line:", line)
-		if line == "{" {
-			buffer = line
-			inject = true
-		} else if line == "}" && inject {
-			buffer += line
-			inject = false
-			pb(buffer)
-			buffer = ""
-		} else if inject {
-			buffer += line
-		}
-	}
-
-}
@@ -0,0 +1,468 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/shundezhang/kubedmp/pkg/dump"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	. "k8s.io/kubectl/pkg/describe"
+)
+
+// resourceType is everything kubedmp knows about a kind: the Kind of pkg/dump,
+// which names it and says where it is found in a dump, and how the command
+// line prints, describes and dumps it.
+type resourceType struct {
+	dump.Kind
+	// printList prints objects of the kind as a table, see print.
+	printList func(items []interface{})
+	// describe prints an object of the kind, decoded into its Go type. Kinds
+	// without one are described by describeGeneric.
+	describe func(obj runtime.Object) (string, error)
+	// list lists the objects of the kind in namespace from the cluster for
+	// dump. It is nil for the kinds kubectl cluster-info dump writes itself.
+	// Dump skips the kind if the cluster does not serve it or forbids listing
+	// it, see listType.
+	list func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error)
+}
+
+// resourceTypes is the registry of kinds: the kinds of pkg/dump, in the order
+// show prints them. Adding a kind takes an entry in the kinds of pkg/dump, and
+// one in kindTypes for a table, a describer or a way to dump it.
+var resourceTypes = newResourceTypes()
+
+// kindTypes is how the command line handles the kinds of pkg/dump, by kind.
+// Kinds without an entry are printed and described generically.
+var kindTypes = map[string]resourceType{
+	"Namespace": {
+		printList: prettyPrintNamespaceList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.namespaceList()
+		},
+	},
+	"Node": {
+		printList: prettyPrintNodeList,
+		describe:  describeDefault,
+	},
+	"PersistentVolume": {
+		printList: prettyPrintPersistentVolumeList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.CoreClient.PersistentVolumes().List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"StorageClass": {
+		printList: prettyPrintStorageClassList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.StorageClient.StorageClasses().List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"ClusterRole": {
+		printList: prettyPrintClusterRoleList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.RbacClient.ClusterRoles().List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"ClusterRoleBinding": {
+		printList: prettyPrintClusterRoleBindingList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.RbacClient.ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"IngressClass": {
+		printList: prettyPrintIngressClassList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.NetworkingClient.IngressClasses().List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"PriorityClass": {
+		printList: prettyPrintPriorityClassList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.SchedulingClient.PriorityClasses().List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"CSIDriver": {
+		printList: prettyPrintCSIDriverList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.StorageClient.CSIDrivers().List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"CSINode": {
+		printList: prettyPrintCSINodeList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.StorageClient.CSINodes().List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"VolumeAttachment": {
+		printList: prettyPrintVolumeAttachmentList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.StorageClient.VolumeAttachments().List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"VolumeSnapshotContent": {
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.listDynamic(kind("VolumeSnapshotContent"), "")
+		},
+	},
+	"ValidatingWebhookConfiguration": {
+		printList: prettyPrintValidatingWebhookConfigurationList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.AdmissionClient.ValidatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"MutatingWebhookConfiguration": {
+		printList: prettyPrintMutatingWebhookConfigurationList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.AdmissionClient.MutatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"APIService": {
+		printList: prettyPrintAPIServiceList,
+		describe:  describeDefault,
+		// client-go has no client for apiregistration.k8s.io, which is in
+		// k8s.io/kube-aggregator
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.listDynamic(kind("APIService"), "")
+		},
+	},
+	"Event": {
+		printList: prettyPrintEventList,
+	},
+	"Service": {
+		printList: prettyPrintServiceList,
+		describe:  describeDefault,
+	},
+	"DaemonSet": {
+		printList: prettyPrintDaemonSetList,
+		describe:  describeDefault,
+	},
+	"Deployment": {
+		printList: prettyPrintDeploymentList,
+		describe:  describeDefault,
+	},
+	"ReplicaSet": {
+		printList: prettyPrintReplicaSetList,
+		describe:  describeDefault,
+	},
+	"StatefulSet": {
+		printList: prettyPrintStatefulSetList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.AppsClient.StatefulSets(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"Pod": {
+		printList: prettyPrintPodList,
+		describe:  describeDefault,
+	},
+	"PersistentVolumeClaim": {
+		printList: prettyPrintPersistentVolumeClaimList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.CoreClient.PersistentVolumeClaims(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"ConfigMap": {
+		printList: prettyPrintConfigMapList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.CoreClient.ConfigMaps(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"Secret": {
+		printList: prettyPrintSecretList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.CoreClient.Secrets(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"ServiceAccount": {
+		printList: prettyPrintServiceAccountList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.CoreClient.ServiceAccounts(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"Ingress": {
+		printList: prettyPrintIngressList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.NetworkingClient.Ingresses(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"Endpoints": {
+		printList: prettyPrintEndpointsList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.CoreClient.Endpoints(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"EndpointSlice": {
+		printList: prettyPrintEndpointSliceList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.DiscoveryClient.EndpointSlices(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"NetworkPolicy": {
+		printList: prettyPrintNetworkPolicyList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.NetworkingClient.NetworkPolicies(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"Job": {
+		printList: prettyPrintJobList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.BatchClient.Jobs(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"CronJob": {
+		printList: prettyPrintCronJobList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.BatchClient.CronJobs(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"VolumeSnapshot": {
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.listDynamic(kind("VolumeSnapshot"), namespace)
+		},
+	},
+	"Role": {
+		printList: prettyPrintRoleList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.RbacClient.Roles(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"RoleBinding": {
+		printList: prettyPrintRoleBindingList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.RbacClient.RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"ResourceQuota": {
+		printList: prettyPrintResourceQuotaList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.CoreClient.ResourceQuotas(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"LimitRange": {
+		printList: prettyPrintLimitRangeList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.CoreClient.LimitRanges(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"HorizontalPodAutoscaler": {
+		printList: prettyPrintHorizontalPodAutoscalerList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.AutoscalingClient.HorizontalPodAutoscalers(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+	"PodDisruptionBudget": {
+		printList: prettyPrintPodDisruptionBudgetList,
+		describe:  describeDefault,
+		list: func(o *ExtraInfoDumpOptions, namespace string) (runtime.Object, error) {
+			return o.PolicyClient.PodDisruptionBudgets(namespace).List(context.TODO(), metav1.ListOptions{})
+		},
+	},
+}
+
+// newResourceTypes returns the kinds of pkg/dump with their entry in
+// kindTypes.
+func newResourceTypes() []*resourceType {
+	for name := range kindTypes {
+		kind(name)
+	}
+	types := []*resourceType{}
+	for _, k := range dump.Kinds() {
+		t := kindTypes[k.Kind]
+		t.Kind = k
+		types = append(types, &t)
+	}
+	return types
+}
+
+// kind returns the Kind of pkg/dump named name.
+func kind(name string) dump.Kind {
+	k, ok := dump.LookupKind(name)
+	if !ok {
+		panic(name + " is not a kind of pkg/dump")
+	}
+	return k
+}
+
+// describeDefault describes obj with the describer of kubectl for its kind,
+// which also prints the objects related to it found in the dump, such as its
+// events. Objects the describer cannot find in the dump, such as those of an
+// API version client-go does not serve, are described on their own, and
+// objects no describer can print are printed field by field.
+func describeDefault(obj runtime.Object) (string, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return "", err
+	}
+	if describer, ok := describerFor(obj.GetObjectKind().GroupVersionKind().GroupKind()); ok {
+		s, err := recovered(func() (string, error) {
+			return describer.Describe(accessor.GetNamespace(), accessor.GetName(), DescriberSettings{ShowEvents: true, ChunkSize: 500})
+		})
+		if err == nil {
+			return s, nil
+		}
+	}
+	s, err := recovered(func() (string, error) {
+		return DefaultObjectDescriber.DescribeObject(obj)
+	})
+	if err == nil {
+		return s, nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err
+	}
+	return describeFields(&unstructured.Unstructured{Object: content})
+}
+
+// recovered runs describe, turning a panic into an error. The describers of
+// kubectl expect the fields the API server defaults, such as the
+// preemptionPolicy of a PriorityClass, which objects written by hand lack.
+func recovered(describe func() (string, error)) (s string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return describe()
+}
+
+// names returns the names the type is given on the command line: its short
+// names, singular and plural, such as "po", "pod" and "pods".
+func (t *resourceType) names() []string {
+	names := append([]string{}, t.ShortNames...)
+	if len(t.Singular) > 0 {
+		names = append(names, t.Singular)
+	}
+	return append(names, t.Resource)
+}
+
+// typed decodes item, an object of the type read from the dump, into its Go
+// type. Objects of kinds client-go does not know are left unstructured.
+func (t *resourceType) typed(item interface{}) (runtime.Object, error) {
+	data, ok := item.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not an object", t.Kind.Kind)
+	}
+	obj, err := scheme.Scheme.New(t.GroupVersionKind())
+	if runtime.IsNotRegisteredError(err) {
+		return &unstructured.Unstructured{Object: data}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(data, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// matches reports whether name is the kind, the plural, optionally with the
+// API group, the singular or one of the short names of the type.
+func (t *resourceType) matches(name string) bool {
+	if contains(t.names(), name) || strings.EqualFold(t.Kind.Kind, name) {
+		return true
+	}
+	return len(t.Group) > 0 && name == t.Resource+"."+t.Group
+}
+
+// lookupType returns the type named name. Kinds not in resourceTypes are
+// looked up in the dump: those defined by a CustomResourceDefinition, then
+// the kinds of the objects in it, taken from its index, whose plural is
+// guessed.
+func lookupType(name string) (*resourceType, error) {
+	for _, t := range resourceTypes {
+		if t.matches(name) {
+			return t, nil
+		}
+	}
+	for _, t := range dumpTypes() {
+		if t.matches(name) {
+			return t, nil
+		}
+	}
+	kinds, err := dumpData.ObjectKinds()
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range kinds {
+		plural, singular := meta.UnsafeGuessKindToResource(k.GroupVersionKind())
+		k.Resource = plural.Resource
+		k.Singular = singular.Resource
+		if t := (&resourceType{Kind: k}); t.matches(name) {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown resource type \"%s\"", name)
+}
+
+// dumpTypes returns resourceTypes followed by the kinds defined by the
+// CustomResourceDefinitions in the dump.
+func dumpTypes() []*resourceType {
+	types := append([]*resourceType{}, resourceTypes...)
+	for _, k := range dumpData.Kinds() {
+		if _, ok := dump.LookupKind(k.Kind); !ok {
+			types = append(types, &resourceType{Kind: k})
+		}
+	}
+	return types
+}
+
+// print prints items, objects of the type, as a table.
+func (t *resourceType) print(items []interface{}) {
+	if t.printList == nil {
+		printGenericList(t, items)
+		return
+	}
+	t.printList(items)
+}
+
+// printTypes prints the registry sorted by API group and kind.
+func printTypes() {
+	types := append([]*resourceType{}, resourceTypes...)
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].Group != types[j].Group {
+			return types[i].Group < types[j].Group
+		}
+		return types[i].Kind.Kind < types[j].Kind.Kind
+	})
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "KIND\tAPIVERSION\tNAMESPACED\tNAMES")
+	for _, t := range types {
+		fmt.Fprintf(writer, "%s\t%s\t%t\t%s\n", t.Kind.Kind, t.APIVersion(), t.Namespaced, strings.Join(t.names(), ","))
+	}
+	writer.Flush()
+}
@@ -0,0 +1,384 @@
+package dump
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	zstdMagic  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	xzMagic    = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+	bzip2Magic = []byte("BZh")
+	zipMagic   = []byte("PK\x03\x04")
+	tarMagic   = []byte("ustar")
+)
+
+const tarMagicOffset = 257
+
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+func (r *readCloser) Close() error {
+	if r.close == nil {
+		return nil
+	}
+	return r.close()
+}
+
+// decompress wraps r with the decompressor matching its magic bytes, or
+// returns it unchanged if it is not compressed. Closing the result does not
+// close r.
+func decompress(r io.Reader) (io.ReadCloser, bool, error) {
+	br := bufio.NewReaderSize(r, 64*1024)
+	magic, _ := br.Peek(len(xzMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, true, err
+		}
+		return gr, true, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, true, err
+		}
+		return &readCloser{Reader: zr, close: func() error { zr.Close(); return nil }}, true, nil
+	case bytes.HasPrefix(magic, xzMagic):
+		xr, err := xz.NewReader(br)
+		if err != nil {
+			return nil, true, err
+		}
+		return &readCloser{Reader: xr}, true, nil
+	case bytes.HasPrefix(magic, bzip2Magic):
+		return &readCloser{Reader: bzip2.NewReader(br)}, true, nil
+	}
+	return &readCloser{Reader: br}, false, nil
+}
+
+// openArchive returns a file system over the members of the tar or zip
+// archive in f, or nil if the file is not an archive. Members are read from f
+// when they are opened; nothing is extracted to disk. f must stay open while
+// the file system is in use.
+func openArchive(f *os.File) (fs.FS, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	magic := make([]byte, len(zipMagic))
+	if _, err := f.ReadAt(magic, 0); err != nil {
+		return nil, nil
+	}
+	if bytes.Equal(magic, zipMagic) {
+		return zip.NewReader(f, info.Size())
+	}
+	r, compressed, err := decompress(io.NewSectionReader(f, 0, info.Size()))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	br := bufio.NewReaderSize(r, tarMagicOffset+len(tarMagic))
+	header, _ := br.Peek(tarMagicOffset + len(tarMagic))
+	if len(header) < tarMagicOffset+len(tarMagic) || !bytes.Equal(header[tarMagicOffset:], tarMagic) {
+		return nil, nil
+	}
+	return newTarFS(f, info.Size(), compressed)
+}
+
+// tarFS is a read-only fs.FS over a tar archive. The member index is built
+// with one pass over the archive. Members of an uncompressed archive are read
+// in place; members of a compressed archive are read by streaming forward to
+// them, so only one member can be read at a time and reading them in archive
+// order is fastest.
+type tarFS struct {
+	file       *os.File
+	size       int64
+	compressed bool
+	members    map[string]*tarMember
+	dirs       map[string][]fs.DirEntry
+
+	stream io.ReadCloser
+	reader *tar.Reader
+	next   int
+}
+
+type tarMember struct {
+	header *tar.Header
+	index  int
+	offset int64
+}
+
+// countingReader tracks the offset of an uncompressed tar stream so that
+// member data can later be read in place.
+type countingReader struct {
+	reader *io.SectionReader
+	offset int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.offset += int64(n)
+	return n, err
+}
+
+func (c *countingReader) Seek(offset int64, whence int) (int64, error) {
+	n, err := c.reader.Seek(offset, whence)
+	if err == nil {
+		c.offset = n
+	}
+	return n, err
+}
+
+func newTarFS(f *os.File, size int64, compressed bool) (*tarFS, error) {
+	t := &tarFS{
+		file:       f,
+		size:       size,
+		compressed: compressed,
+		members:    map[string]*tarMember{},
+		dirs:       map[string][]fs.DirEntry{},
+	}
+
+	var stream io.Reader
+	counter := &countingReader{reader: io.NewSectionReader(f, 0, size)}
+	if compressed {
+		r, _, err := decompress(counter.reader)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		stream = r
+	} else {
+		stream = counter
+	}
+
+	seen := map[string]bool{}
+	tr := tar.NewReader(stream)
+	for index := 0; ; index++ {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := cleanMemberName(header.Name)
+		if name == "." {
+			continue
+		}
+		t.members[name] = &tarMember{header: header, index: index, offset: counter.offset}
+		t.addDirEntry(name, fs.FileInfoToDirEntry(header.FileInfo()), seen)
+	}
+	for dir := range t.dirs {
+		entries := t.dirs[dir]
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	}
+	return t, nil
+}
+
+// addDirEntry registers name in its parent directory, creating parent
+// directories that have no header of their own.
+func (t *tarFS) addDirEntry(name string, entry fs.DirEntry, seen map[string]bool) {
+	for {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		parent := path.Dir(name)
+		t.dirs[parent] = append(t.dirs[parent], entry)
+		if parent == "." {
+			return
+		}
+		if _, ok := t.members[parent]; ok {
+			return
+		}
+		name = parent
+		entry = fs.FileInfoToDirEntry(implicitDir(path.Base(parent)))
+	}
+}
+
+func cleanMemberName(name string) string {
+	return path.Clean(strings.TrimPrefix(strings.TrimPrefix(name, "/"), "./"))
+}
+
+// resolve follows symbolic links inside the archive.
+func (t *tarFS) resolve(name string) (string, *tarMember) {
+	for i := 0; i < 16; i++ {
+		member, ok := t.members[name]
+		if !ok || member.header.Typeflag != tar.TypeSymlink {
+			return name, member
+		}
+		target := member.header.Linkname
+		if !path.IsAbs(target) {
+			target = path.Join(path.Dir(name), target)
+		}
+		name = cleanMemberName(target)
+	}
+	return name, nil
+}
+
+func (t *tarFS) isDir(name string) bool {
+	if name == "." {
+		return true
+	}
+	if _, ok := t.dirs[name]; ok {
+		return true
+	}
+	member, ok := t.members[name]
+	return ok && member.header.Typeflag == tar.TypeDir
+}
+
+func (t *tarFS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	name, member := t.resolve(name)
+	if member != nil {
+		return member.header.FileInfo(), nil
+	}
+	if t.isDir(name) {
+		return implicitDir(path.Base(name)), nil
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+func (t *tarFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	name, _ = t.resolve(name)
+	if !t.isDir(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	entries := make([]fs.DirEntry, len(t.dirs[name]))
+	copy(entries, t.dirs[name])
+	return entries, nil
+}
+
+func (t *tarFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	resolved, member := t.resolve(name)
+	if t.isDir(resolved) {
+		info, _ := t.Stat(resolved)
+		entries, _ := t.ReadDir(resolved)
+		return &tarDir{info: info, entries: entries}, nil
+	}
+	if member == nil || !member.header.FileInfo().Mode().IsRegular() {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if !t.compressed {
+		return &tarFile{info: member.header.FileInfo(), reader: io.NewSectionReader(t.file, member.offset, member.header.Size)}, nil
+	}
+	if err := t.seekMember(member); err != nil {
+		return nil, err
+	}
+	return &tarFile{info: member.header.FileInfo(), reader: t.reader}, nil
+}
+
+// seekMember moves the compressed stream to the data of member, starting
+// over from the beginning of the archive if it has already been passed.
+func (t *tarFS) seekMember(member *tarMember) error {
+	if t.reader == nil || member.index < t.next {
+		if err := t.restart(); err != nil {
+			return err
+		}
+	}
+	for t.next <= member.index {
+		if _, err := t.reader.Next(); err != nil {
+			if err == io.EOF {
+				return &fs.PathError{Op: "open", Path: member.header.Name, Err: fs.ErrNotExist}
+			}
+			return err
+		}
+		t.next++
+	}
+	return nil
+}
+
+func (t *tarFS) restart() error {
+	t.Close()
+	r, _, err := decompress(io.NewSectionReader(t.file, 0, t.size))
+	if err != nil {
+		return err
+	}
+	t.stream = r
+	t.reader = tar.NewReader(r)
+	t.next = 0
+	return nil
+}
+
+func (t *tarFS) Close() error {
+	if t.stream != nil {
+		t.stream.Close()
+		t.stream = nil
+	}
+	t.reader = nil
+	return nil
+}
+
+type tarFile struct {
+	info   fs.FileInfo
+	reader io.Reader
+}
+
+func (f *tarFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *tarFile) Read(p []byte) (int, error) { return f.reader.Read(p) }
+func (f *tarFile) Close() error               { return nil }
+
+type tarDir struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+}
+
+func (d *tarDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *tarDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: errors.New("is a directory")}
+}
+func (d *tarDir) Close() error { return nil }
+
+func (d *tarDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(d.entries) {
+		n = len(d.entries)
+	}
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
+
+// implicitDir describes a directory that only exists as the parent of
+// archive members.
+type implicitDir string
+
+func (d implicitDir) Name() string       { return string(d) }
+func (d implicitDir) Size() int64        { return 0 }
+func (d implicitDir) Mode() fs.FileMode  { return fs.ModeDir | 0555 }
+func (d implicitDir) ModTime() time.Time { return time.Time{} }
+func (d implicitDir) IsDir() bool        { return true }
+func (d implicitDir) Sys() interface{}   { return nil }
@@ -0,0 +1,147 @@
+package dump
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+const (
+	ManifestAPIVersion = "kubedmp/v1"
+	ManifestKind       = "DumpManifest"
+	// ManifestFile is the name, without extension, of the file the manifest
+	// is written to in a dump directory.
+	ManifestFile = "kubedmp-manifest"
+)
+
+// Manifest is written by kubedmp dump ahead of the objects: as the first
+// document on stdout, or as kubedmp-manifest.json in the output directory.
+type Manifest struct {
+	APIVersion  string    `json:"apiVersion"`
+	Kind        string    `json:"kind"`
+	CaptureTime time.Time `json:"captureTime"`
+	Version     string    `json:"version,omitempty"`
+}
+
+// CaptureTime returns when the dump was taken: the time in its manifest, or
+// else the newest node or event timestamp in it. With several dumps the
+// newest capture time is returned. It is zero if the dump has neither.
+func (d *Dump) CaptureTime() time.Time {
+	captureTime := time.Time{}
+	for _, s := range d.sources {
+		captureTime = laterTime(captureTime, s.captureTime())
+	}
+	return captureTime
+}
+
+func (s *source) captureTime() time.Time {
+	if t := s.manifestTime(); !t.IsZero() {
+		return t
+	}
+	newest := time.Time{}
+	for _, kind := range []string{"Node", "Event"} {
+		filePaths, _ := s.kindFiles(kind, "")
+		for _, filePath := range filePaths {
+			newest = laterTime(newest, s.newestIn(filePath))
+		}
+	}
+	return newest
+}
+
+// manifestTime returns the capture time in the manifest of the dump, or zero
+// if it has none.
+func (s *source) manifestTime() time.Time {
+	filePath := s.root
+	switch s.layout {
+	case layoutFile:
+	case layoutClusterInfoDir:
+		filePath = s.filePath(s.root, ManifestFile)
+	default:
+		return time.Time{}
+	}
+	f, err := s.open(filePath)
+	if err != nil {
+		return time.Time{}
+	}
+	defer f.Close()
+	doc, err := newDocDecoder(f).Next()
+	if err != nil {
+		return time.Time{}
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(doc.Data, &manifest); err != nil || manifest.Kind != ManifestKind {
+		return time.Time{}
+	}
+	return manifest.CaptureTime
+}
+
+func (s *source) noteNewest(filePath string, newest time.Time) {
+	s.newest[filePath] = laterTime(s.newest[filePath], newest)
+}
+
+// newestIn returns the newest timestamp in filePath, scanning the file if it
+// has not been read yet.
+func (s *source) newestIn(filePath string) time.Time {
+	if t, ok := s.newest[filePath]; ok {
+		return t
+	}
+	if file := s.lookupIndex(filePath); file != nil {
+		return file.Newest
+	}
+	s.buildIndex(filePath)
+	return s.newest[filePath]
+}
+
+// newestTimestamp returns the newest RFC 3339 timestamp in a JSON document
+// held by a field named like a time, such as creationTimestamp,
+// lastHeartbeatTime or startedAt. Timestamps in the future are ignored.
+func newestTimestamp(data []byte) time.Time {
+	newest := time.Time{}
+	now := time.Now()
+	for i := 0; i < len(data); {
+		j := bytes.IndexByte(data[i:], 'T')
+		if j < 0 {
+			break
+		}
+		j += i
+		i = j + 1
+		// "2006-01-02T15:04:05Z"
+		if j < 11 || data[j-11] != '"' || data[j-6] != '-' || data[j-3] != '-' {
+			continue
+		}
+		end := bytes.IndexByte(data[j:], '"')
+		if end < 0 || end > 32 || !isTimeField(data[:j-11]) {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, string(data[j-10:j+end]))
+		if err != nil || t.After(now) {
+			continue
+		}
+		newest = laterTime(newest, t)
+	}
+	return newest
+}
+
+// isTimeField reports whether data ends with a field name like a time,
+// followed by the colon before its value.
+func isTimeField(data []byte) bool {
+	data = bytes.TrimRight(data, " \t\r\n")
+	if !bytes.HasSuffix(data, []byte(":")) {
+		return false
+	}
+	data = bytes.TrimRight(data[:len(data)-1], " \t\r\n")
+	if !bytes.HasSuffix(data, []byte(`"`)) {
+		return false
+	}
+	data = data[:len(data)-1]
+	name := data[bytes.LastIndexByte(data, '"')+1:]
+	return bytes.HasSuffix(name, []byte("Time")) || bytes.HasSuffix(name, []byte("Timestamp")) ||
+		bytes.HasSuffix(name, []byte("At")) || bytes.Equal(name, []byte("time"))
+}
+
+func laterTime(a time.Time, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
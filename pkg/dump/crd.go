@@ -0,0 +1,140 @@
+package dump
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Kinds returns the kinds kubedmp knows followed by the kinds defined by the
+// CustomResourceDefinitions in the dump.
+func (d *Dump) Kinds() []Kind {
+	return append(Kinds(), d.customKinds()...)
+}
+
+// LookupKind returns the Kind named kind, either known to kubedmp or defined
+// by a CustomResourceDefinition in the dump.
+func (d *Dump) LookupKind(kind string) (Kind, bool) {
+	if k, ok := LookupKind(kind); ok {
+		return k, true
+	}
+	for _, k := range d.customKinds() {
+		if k.Kind == kind {
+			return k, true
+		}
+	}
+	return Kind{}, false
+}
+
+// ObjectKinds returns the kinds of the objects in the dump, known or not,
+// with the group and version they were written with. Only Kind, Group,
+// Version and Namespaced are set. The kinds are taken from the index of the
+// dump, which is built the first time; files that cannot be indexed, such as
+// compressed ones, are read in full.
+func (d *Dump) ObjectKinds() ([]Kind, error) {
+	found := []Kind{}
+	seen := map[string]bool{}
+	for _, s := range d.sources {
+		entries, err := s.objectKinds()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			gv, err := schema.ParseGroupVersion(entry.APIVersion)
+			if err != nil || len(entry.Kind) == 0 || seen[gv.Group+"/"+entry.Kind] {
+				continue
+			}
+			seen[gv.Group+"/"+entry.Kind] = true
+			found = append(found, Kind{Kind: entry.Kind, Group: gv.Group, Version: gv.Version, Namespaced: len(entry.Namespace) > 0})
+		}
+	}
+	return found, nil
+}
+
+func (d *Dump) namespaced(kind string) bool {
+	k, ok := d.LookupKind(kind)
+	return !ok || k.Namespaced
+}
+
+// customKinds reads the CustomResourceDefinitions of the dump once.
+func (d *Dump) customKinds() []Kind {
+	if d.custom != nil {
+		return d.custom
+	}
+	d.custom = []Kind{}
+	crds, err := d.list(filter{kinds: []string{"CustomResourceDefinition"}})
+	if err != nil {
+		d.Warnf("Warning: skipped the CustomResourceDefinitions of the dump, %v", err)
+		return d.custom
+	}
+	for _, crd := range crds {
+		k, ok := crdKind(crd.Object)
+		if !ok {
+			continue
+		}
+		if _, known := LookupKind(k.Kind); !known {
+			d.custom = append(d.custom, k)
+		}
+	}
+	return d.custom
+}
+
+// crdKind returns the Kind a CustomResourceDefinition defines, taking the
+// version and printer columns of its storage version. Both apiextensions v1
+// and v1beta1 are read.
+func crdKind(crd map[string]interface{}) (Kind, bool) {
+	spec, _ := crd["spec"].(map[string]interface{})
+	names, _ := spec["names"].(map[string]interface{})
+	k := Kind{}
+	k.Kind, _ = names["kind"].(string)
+	k.Resource, _ = names["plural"].(string)
+	k.Singular, _ = names["singular"].(string)
+	k.Group, _ = spec["group"].(string)
+	if len(k.Kind) == 0 || len(k.Resource) == 0 {
+		return k, false
+	}
+	if len(k.Singular) == 0 {
+		k.Singular = strings.ToLower(k.Kind)
+	}
+	shortNames, _ := names["shortNames"].([]interface{})
+	for _, name := range shortNames {
+		if s, ok := name.(string); ok {
+			k.ShortNames = append(k.ShortNames, s)
+		}
+	}
+	scope, _ := spec["scope"].(string)
+	k.Namespaced = scope != "Cluster"
+	k.FileName = k.Resource
+
+	k.Version, _ = spec["version"].(string)
+	columns, _ := spec["additionalPrinterColumns"].([]interface{})
+	versions, _ := spec["versions"].([]interface{})
+	var storageVersion map[string]interface{}
+	for _, v := range versions {
+		version, _ := v.(map[string]interface{})
+		if storage, _ := version["storage"].(bool); storage || storageVersion == nil {
+			storageVersion = version
+		}
+	}
+	if storageVersion != nil {
+		k.Version, _ = storageVersion["name"].(string)
+		if versionColumns, ok := storageVersion["additionalPrinterColumns"].([]interface{}); ok {
+			columns = versionColumns
+		}
+	}
+	for _, c := range columns {
+		column, _ := c.(map[string]interface{})
+		pc := PrinterColumn{}
+		pc.Name, _ = column["name"].(string)
+		pc.Type, _ = column["type"].(string)
+		if pc.JSONPath, _ = column["jsonPath"].(string); len(pc.JSONPath) == 0 {
+			pc.JSONPath, _ = column["JSONPath"].(string)
+		}
+		priority, _ := column["priority"].(float64)
+		pc.Priority = int64(priority)
+		if len(pc.Name) > 0 && len(pc.JSONPath) > 0 {
+			k.PrinterColumns = append(k.PrinterColumns, pc)
+		}
+	}
+	return k, true
+}
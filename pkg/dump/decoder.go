@@ -0,0 +1,286 @@
+package dump
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	logStartMarker = "==== START logs for container"
+	logEndMarker   = "==== END logs for container"
+
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+// dumpDoc is a top-level JSON document found in a dump stream. Offset is the
+// byte offset of Data in the stream, or -1 if Data was converted from YAML.
+type dumpDoc struct {
+	Line   int
+	Offset int64
+	Data   []byte
+}
+
+// logSection is a "==== START logs" section found in a dump stream, from the
+// start of its START line to the end of its END line.
+type logSection struct {
+	Marker string
+	Offset int64
+	Length int64
+}
+
+// regionError describes a part of a dump stream that could not be parsed.
+type regionError struct {
+	StartLine int
+	EndLine   int
+	Reason    string
+}
+
+func (e *regionError) Error() string {
+	if e.StartLine == e.EndLine {
+		return fmt.Sprintf("line %d: %s", e.StartLine, e.Reason)
+	}
+	return fmt.Sprintf("lines %d-%d: %s", e.StartLine, e.EndLine, e.Reason)
+}
+
+// docDecoder finds every top-level object in a stream that mixes JSON or
+// YAML documents and "==== START logs" sections, regardless of how the JSON is
+// formatted. The format is detected from the first document: lines are taken
+// to be YAML until one starts a JSON object, and the lines before it are then
+// skipped. YAML documents are converted to JSON. Regions that are neither are
+// returned as *regionError.
+type docDecoder struct {
+	reader *bufio.Reader
+	line   int
+	eof    bool
+	format string
+
+	// offset is the byte offset of the next line, lineStart that of the
+	// line being scanned.
+	offset    int64
+	lineStart int64
+
+	inLogs   bool
+	logStart int64
+	logLine  string
+	sections []logSection
+
+	buf      bytes.Buffer
+	stack    []byte
+	inString bool
+	escaped  bool
+	docLine  int
+	docStart int64
+
+	garbageStart int
+	garbageEnd   int
+
+	pending []interface{}
+}
+
+func newDocDecoder(r io.Reader) *docDecoder {
+	return &docDecoder{reader: bufio.NewReaderSize(r, 1024*1024)}
+}
+
+// Next returns the next document. It returns a *regionError for unparsable
+// content, after which decoding may continue, and io.EOF at the end.
+func (d *docDecoder) Next() (*dumpDoc, error) {
+	for len(d.pending) == 0 {
+		if d.eof {
+			return nil, io.EOF
+		}
+		if err := d.readLine(); err != nil {
+			return nil, err
+		}
+	}
+	next := d.pending[0]
+	d.pending = d.pending[1:]
+	switch v := next.(type) {
+	case *dumpDoc:
+		return v, nil
+	case *regionError:
+		return nil, v
+	}
+	return nil, io.EOF
+}
+
+func (d *docDecoder) readLine() error {
+	line, err := d.reader.ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if err == io.EOF {
+		d.eof = true
+		if len(line) == 0 {
+			d.finish()
+			return nil
+		}
+	}
+	d.line++
+	d.lineStart = d.offset
+	d.offset += int64(len(line))
+	d.scanLine(line)
+	if d.eof {
+		d.finish()
+	}
+	return nil
+}
+
+func (d *docDecoder) scanLine(line []byte) {
+	if d.inLogs {
+		if bytes.HasPrefix(line, []byte(logEndMarker)) {
+			d.endLogs()
+		}
+		return
+	}
+	if bytes.HasPrefix(line, []byte(logStartMarker)) {
+		d.abandonDoc("unterminated document", d.line-1)
+		d.flushGarbage()
+		d.flushYAML(d.line - 1)
+		d.inLogs = true
+		d.logStart = d.lineStart
+		d.logLine = string(bytes.TrimRight(line, "\r\n"))
+		return
+	}
+	if d.format == "" {
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) == 0 && d.buf.Len() == 0 {
+			return
+		}
+		if len(trimmed) == 0 || trimmed[0] != '{' {
+			d.scanYAMLLine(line)
+			return
+		}
+		// lines before the first JSON document, such as a line a tool
+		// printed before the dump, are skipped
+		if d.buf.Len() > 0 {
+			d.garbageStart, d.garbageEnd = d.docLine, d.line-1
+			d.buf.Reset()
+		}
+		d.format = formatJSON
+	}
+	if d.format == formatYAML {
+		d.scanYAMLLine(line)
+		return
+	}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if len(d.stack) == 0 {
+			switch c {
+			case ' ', '\t', '\r', '\n':
+				continue
+			case '{':
+				d.flushGarbage()
+				d.stack = append(d.stack[:0], c)
+				d.docLine = d.line
+				d.docStart = d.lineStart + int64(i)
+				d.buf.Reset()
+				d.buf.WriteByte(c)
+			default:
+				if d.garbageStart == 0 {
+					d.garbageStart = d.line
+				}
+				d.garbageEnd = d.line
+			}
+			continue
+		}
+
+		d.buf.WriteByte(c)
+		if d.inString {
+			if d.escaped {
+				d.escaped = false
+			} else if c == '\\' {
+				d.escaped = true
+			} else if c == '"' {
+				d.inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			d.inString = true
+		case '{', '[':
+			d.stack = append(d.stack, c)
+		case '}', ']':
+			if open := d.stack[len(d.stack)-1]; (open == '{') != (c == '}') {
+				d.abandonDoc(fmt.Sprintf("mismatched '%c'", c), d.line)
+				continue
+			}
+			d.stack = d.stack[:len(d.stack)-1]
+			if len(d.stack) == 0 {
+				data := make([]byte, d.buf.Len())
+				copy(data, d.buf.Bytes())
+				d.pending = append(d.pending, &dumpDoc{Line: d.docLine, Offset: d.docStart, Data: data})
+				d.buf.Reset()
+			}
+		}
+	}
+}
+
+func (d *docDecoder) abandonDoc(reason string, endLine int) {
+	if len(d.stack) == 0 {
+		return
+	}
+	d.pending = append(d.pending, &regionError{StartLine: d.docLine, EndLine: endLine, Reason: reason})
+	d.stack = d.stack[:0]
+	d.inString = false
+	d.escaped = false
+	d.buf.Reset()
+}
+
+func (d *docDecoder) flushGarbage() {
+	if d.garbageStart == 0 {
+		return
+	}
+	d.pending = append(d.pending, &regionError{StartLine: d.garbageStart, EndLine: d.garbageEnd, Reason: "content is not a JSON document"})
+	d.garbageStart = 0
+	d.garbageEnd = 0
+}
+
+func (d *docDecoder) scanYAMLLine(line []byte) {
+	trimmed := bytes.TrimRight(line, " \t\r\n")
+	if bytes.Equal(trimmed, []byte("---")) || bytes.HasPrefix(trimmed, []byte("--- ")) || bytes.Equal(trimmed, []byte("...")) {
+		d.flushYAML(d.line - 1)
+		return
+	}
+	if d.buf.Len() == 0 {
+		d.docLine = d.line
+	}
+	d.buf.Write(line)
+}
+
+func (d *docDecoder) flushYAML(endLine int) {
+	if d.buf.Len() == 0 {
+		return
+	}
+	data, err := yaml.YAMLToJSON(d.buf.Bytes())
+	d.buf.Reset()
+	if err != nil {
+		d.pending = append(d.pending, &regionError{StartLine: d.docLine, EndLine: endLine, Reason: err.Error()})
+		return
+	}
+	if bytes.Equal(data, []byte("null")) {
+		return
+	}
+	d.format = formatYAML
+	d.pending = append(d.pending, &dumpDoc{Line: d.docLine, Offset: -1, Data: data})
+}
+
+// endLogs records the log section ending at the current line.
+func (d *docDecoder) endLogs() {
+	d.inLogs = false
+	d.sections = append(d.sections, logSection{Marker: d.logLine, Offset: d.logStart, Length: d.offset - d.logStart})
+}
+
+func (d *docDecoder) finish() {
+	if d.inLogs {
+		d.endLogs()
+	}
+	d.abandonDoc("unterminated document at end of file", d.line)
+	d.flushGarbage()
+	d.flushYAML(d.line)
+}
@@ -0,0 +1,213 @@
+package dump
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// decodeAll returns the documents of input, compacted, and the errors found
+// in it.
+func decodeAll(t *testing.T, input string) ([]string, []string) {
+	t.Helper()
+	decoder := newDocDecoder(strings.NewReader(input))
+	docs := []string{}
+	errs := []string{}
+	for {
+		doc, err := decoder.Next()
+		if err == io.EOF {
+			return docs, errs
+		}
+		if region, ok := err.(*regionError); ok {
+			errs = append(errs, region.Error())
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Next() error: %v", err)
+		}
+		var v interface{}
+		if err := json.Unmarshal(doc.Data, &v); err != nil {
+			t.Fatalf("document at line %d is not valid JSON: %v", doc.Line, err)
+		}
+		data, _ := json.Marshal(v)
+		docs = append(docs, string(data))
+	}
+}
+
+func TestDocDecoder(t *testing.T) {
+	podList := `{"kind":"PodList","items":[{"metadata":{"name":"a"}},{"metadata":{"name":"b"}}]}`
+	service := `{"kind":"Service","metadata":{"name":"s"}}`
+	tests := []struct {
+		name  string
+		input string
+		docs  []string
+		errs  []string
+	}{
+		{
+			name:  "compact",
+			input: podList + service + "\n",
+			docs:  []string{podList, service},
+		},
+		{
+			name: "indented",
+			input: `{
+    "kind": "PodList",
+    "items": [
+        {
+            "metadata": {
+                "name": "a"
+            }
+        },
+        {
+            "metadata": {
+                "name": "b"
+            }
+        }
+    ]
+}
+` + service + "\n",
+			docs: []string{podList, service},
+		},
+		{
+			name: "indent=0",
+			input: `{
+"kind": "PodList",
+"items": [
+{
+"metadata": {
+"name": "a"
+}
+},
+{
+"metadata": {
+"name": "b"
+}
+}
+]
+}
+{
+"kind": "Service",
+"metadata": {
+"name": "s"
+}
+}
+`,
+			docs: []string{podList, service},
+		},
+		{
+			name: "item at column 0",
+			input: `{
+    "kind": "PodList",
+    "items": [
+        {"metadata": {"name": "a"}},
+{"metadata": {"name": "b"}}
+    ]
+}
+`,
+			docs: []string{podList},
+		},
+		{
+			name:  "re-wrapped",
+			input: "{\"kind\":\n\"PodList\",\"items\":\n[{\"metadata\":{\"name\":\n\"a\"}},{\"metadata\"\n:{\"name\":\"b\"}}]}" + service + "\n",
+			docs:  []string{podList, service},
+		},
+		{
+			name:  "braces in strings",
+			input: `{"kind":"ConfigMap","data":{"a":"{[\"}"}}` + "\n",
+			docs:  []string{`{"data":{"a":"{[\"}"},"kind":"ConfigMap"}`},
+		},
+		{
+			name: "logs between documents",
+			input: service + `
+==== START logs for container c of pod default/web ====
+{ not json
+==== END logs for container c of pod default/web ====
+` + service + "\n",
+			docs: []string{service, service},
+		},
+		{
+			name:  "truncated at end of input",
+			input: service + "\n" + `{"kind": "PodList", "items": [` + "\n",
+			docs:  []string{service},
+			errs:  []string{"line 2: unterminated document at end of file"},
+		},
+		{
+			name: "truncated before logs",
+			input: `{"kind": "PodList", "items": [
+==== START logs for container c of pod default/web ====
+==== END logs for container c of pod default/web ====
+` + service + "\n",
+			docs: []string{service},
+			errs: []string{"line 1: unterminated document"},
+		},
+		{
+			name:  "garbage",
+			input: service + "\nhello\n" + service + "\n",
+			docs:  []string{service, service},
+			errs:  []string{"line 2: content is not a JSON document"},
+		},
+		{
+			name:  "garbage before a JSON list",
+			input: "Dumping cluster state\nto stdout:\n{\n  \"kind\": \"PodList\",\n  \"items\": [{\"metadata\": {\"name\": \"a\"}}, {\"metadata\": {\"name\": \"b\"}}]\n}\n" + service + "\n",
+			docs:  []string{podList, service},
+			errs:  []string{"lines 1-2: content is not a JSON document"},
+		},
+		{
+			name:  "yaml",
+			input: "kind: Service\nmetadata:\n  name: s\n---\nkind: Service\nmetadata:\n  name: s\n",
+			docs:  []string{service, service},
+		},
+		{
+			name:  "yaml after blank lines",
+			input: "\n\nkind: Service\nmetadata:\n  name: s\n",
+			docs:  []string{service},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			docs, errs := decodeAll(t, test.input)
+			want := []string{}
+			for _, doc := range test.docs {
+				var v interface{}
+				if err := json.Unmarshal([]byte(doc), &v); err != nil {
+					t.Fatalf("bad test document %s: %v", doc, err)
+				}
+				data, _ := json.Marshal(v)
+				want = append(want, string(data))
+			}
+			if !reflect.DeepEqual(docs, want) {
+				t.Errorf("documents = %v, want %v", docs, want)
+			}
+			if test.errs == nil {
+				test.errs = []string{}
+			}
+			if !reflect.DeepEqual(errs, test.errs) {
+				t.Errorf("errors = %v, want %v", errs, test.errs)
+			}
+		})
+	}
+}
+
+func TestDocDecoderSections(t *testing.T) {
+	input := `{"kind":"Service","metadata":{"name":"s"}}
+==== START logs for container c of pod default/web ====
+line 1
+==== END logs for container c of pod default/web ====
+`
+	decoder := newDocDecoder(strings.NewReader(input))
+	for {
+		if _, err := decoder.Next(); err == io.EOF {
+			break
+		}
+	}
+	want := []logSection{{
+		Marker: "==== START logs for container c of pod default/web ====",
+		Offset: 43,
+		Length: int64(len(input) - 43),
+	}}
+	if !reflect.DeepEqual(decoder.sections, want) {
+		t.Errorf("sections = %+v, want %+v", decoder.sections, want)
+	}
+}
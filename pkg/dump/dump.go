@@ -0,0 +1,236 @@
+// Package dump reads Kubernetes cluster dumps: the file or directory written
+// by kubectl cluster-info dump or kubedmp dump, the kubernetes plugin of a
+// sosreport, or an oc adm must-gather directory, optionally compressed or in
+// a tar or zip archive.
+//
+//	d, err := dump.Open("cluster-info.dump")
+//	if err != nil {
+//		return err
+//	}
+//	defer d.Close()
+//	pods, err := d.List("Pod", "kube-system", nil, nil)
+package dump
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// SourceKey is set on every object returned by a Dump to the name of the dump
+// it was read from. It is not a valid field of any object, so decoding the
+// object into its Go type drops it.
+const SourceKey = "kubedmp.source"
+
+// SynthesizedKey is set to true on the objects a Dump makes up instead of
+// reading them, such as the Namespaces of a dump without Namespace objects.
+// Like SourceKey, decoding the object into its Go type drops it.
+const SynthesizedKey = "kubedmp.synthesized"
+
+// ErrNotFound is returned, wrapped, when an object or a log is not in the
+// dump.
+var ErrNotFound = errors.New("not found")
+
+// Dump is one or more dumps read as one.
+type Dump struct {
+	// Warnf reports content of the dump that was skipped because it could not
+	// be parsed. Open sets it to log.Printf.
+	Warnf func(format string, args ...interface{})
+
+	sources []*source
+	closers []io.Closer
+	// custom holds the kinds defined by the CustomResourceDefinitions of the
+	// dump once they are read.
+	custom []Kind
+}
+
+// Open opens the dumps at paths as one Dump. Each path is a dump file, a dump
+// directory, or an archive of either, and "-" reads the dump from stdin.
+// Objects found in more than one dump are returned once, see List.
+func Open(paths ...string) (*Dump, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no dump is given")
+	}
+	d := &Dump{Warnf: log.Printf}
+	for _, location := range paths {
+		if location == StdinPath && d.readingStdin() {
+			return nil, errors.New("the dump can only be read from stdin once")
+		}
+		s, err := d.openSource(location)
+		if err != nil {
+			d.Close()
+			return nil, err
+		}
+		d.sources = append(d.sources, s)
+	}
+	return d, nil
+}
+
+func (d *Dump) readingStdin() bool {
+	for _, s := range d.sources {
+		if s.name == stdinName {
+			return true
+		}
+	}
+	return false
+}
+
+// Close releases the archives and the copy of stdin the dump is read from.
+func (d *Dump) Close() error {
+	var err error
+	for _, closer := range d.closers {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	d.closers = nil
+	return err
+}
+
+// List returns the objects of kind in namespace that match the label and
+// field selectors. An empty kind lists every kind, an empty namespace lists
+// every namespace, and a nil selector matches every object. The namespace is
+// ignored for cluster scoped kinds. A field selector can name any field of
+// the objects, such as status.phase or spec.nodeName.
+//
+// An object found in more than one dump, by its kind and UID, is returned once,
+// taking the copy with the newest resourceVersion.
+//
+// Namespaces without a Namespace object in the dump are listed too, with only
+// their name.
+func (d *Dump) List(kind string, namespace string, selector labels.Selector, fieldSelector fields.Selector) ([]*unstructured.Unstructured, error) {
+	if len(kind) == 0 {
+		return d.ListKinds(nil, namespace, selector, fieldSelector)
+	}
+	if !d.namespaced(kind) {
+		namespace = ""
+	}
+	return d.ListKinds([]string{kind}, namespace, selector, fieldSelector)
+}
+
+// ListKinds is List for several kinds at once. A dump kept in a single file
+// is read once for all of them.
+func (d *Dump) ListKinds(kinds []string, namespace string, selector labels.Selector, fieldSelector fields.Selector) ([]*unstructured.Unstructured, error) {
+	return d.list(filter{kinds: kinds, namespace: namespace, selector: selector, fields: fieldSelector})
+}
+
+// Get returns the object of kind with name in namespace. The namespace is
+// ignored for cluster scoped kinds.
+func (d *Dump) Get(kind string, namespace string, name string) (*unstructured.Unstructured, error) {
+	if !d.namespaced(kind) {
+		namespace = ""
+	}
+	objects, err := d.list(filter{kinds: []string{kind}, namespace: namespace, name: name})
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		if len(namespace) > 0 {
+			return nil, fmt.Errorf("%s %s/%s: %w", kind, namespace, name, ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s %s: %w", kind, name, ErrNotFound)
+	}
+	return objects[0], nil
+}
+
+func (d *Dump) list(f filter) ([]*unstructured.Unstructured, error) {
+	objects := []map[string]interface{}{}
+	for _, s := range d.sources {
+		found, err := s.list(f)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, found...)
+	}
+	if len(d.sources) > 1 {
+		objects = dedupe(objects)
+	}
+	if containsKind(f.kinds, "Namespace") {
+		discovered, err := d.discoveredNamespaces(objects, f)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, discovered...)
+	}
+	items := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, obj := range objects {
+		items = append(items, &unstructured.Unstructured{Object: obj})
+	}
+	return items, nil
+}
+
+// Logs returns the logs of a container of a pod. The container can be left
+// empty if the pod has only one, or, in a cluster-info dump, to read the logs
+// of all its containers. Logs are read from the first dump that has them; it
+// returns ErrNotFound if none has a log file or section for the pod.
+func (d *Dump) Logs(namespace string, pod string, container string) (io.ReadCloser, error) {
+	found := false
+	for _, s := range d.sources {
+		logFile, marker, err := s.podLogFile(namespace, pod, container)
+		if err != nil {
+			return nil, err
+		}
+		if len(logFile) == 0 {
+			continue
+		}
+		r, err := s.logs(logFile, marker)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", s.location(logFile), err)
+		}
+		br := bufio.NewReader(r)
+		if _, err := br.Peek(1); err == nil {
+			return &readCloser{Reader: br, close: r.Close}, nil
+		}
+		r.Close()
+		// an empty log file is an empty log, while a dump file without a
+		// section for the pod does not have its logs
+		if len(marker) == 0 {
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("logs of pod %s/%s: %w", namespace, pod, ErrNotFound)
+	}
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+// Typed converts an object returned by a Dump to its Go type, such as
+// *corev1.Pod. Kinds not known to client-go are returned unchanged.
+func Typed(obj *unstructured.Unstructured) (runtime.Object, error) {
+	typed, err := scheme.Scheme.New(obj.GroupVersionKind())
+	if err != nil {
+		if runtime.IsNotRegisteredError(err) {
+			return obj, nil
+		}
+		return nil, err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, typed); err != nil {
+		return nil, err
+	}
+	return typed, nil
+}
+
+// Synthesized reports whether obj was made up by a Dump, see SynthesizedKey.
+func Synthesized(obj map[string]interface{}) bool {
+	synthesized, _ := obj[SynthesizedKey].(bool)
+	return synthesized
+}
+
+// Source returns the dump obj was read from, see SourceKey.
+func Source(obj map[string]interface{}) string {
+	source, _ := obj[SourceKey].(string)
+	return source
+}
@@ -0,0 +1,301 @@
+package dump
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const testPods = `{"kind":"PodList","apiVersion":"v1","items":[
+{"metadata":{"name":"web","namespace":"default","uid":"1"}},
+{"metadata":{"name":"web-1","namespace":"default","uid":"2"}}]}
+`
+
+const testLogs = `==== START logs for container c of pod default/web ====
+web c
+==== END logs for container c of pod default/web ====
+==== START logs for container d of pod default/web ====
+web d
+==== END logs for container d of pod default/web ====
+==== START logs for container c of pod default/web-1 ====
+web-1 c
+==== END logs for container c of pod default/web-1 ====
+`
+
+// writeDump writes content to a file in a temporary directory and returns its
+// path.
+func writeDump(t *testing.T, name string, content string) string {
+	t.Helper()
+	filePath := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filePath
+}
+
+func openDump(t *testing.T, paths ...string) *Dump {
+	t.Helper()
+	d, err := Open(paths...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Warnf = t.Logf
+	t.Cleanup(func() { d.Close() })
+	return d
+}
+
+func names(t *testing.T, d *Dump, kind string) []string {
+	t.Helper()
+	objects, err := d.List(kind, "", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, obj := range objects {
+		names = append(names, obj.GetName())
+	}
+	return names
+}
+
+func TestLogs(t *testing.T) {
+	filePath := writeDump(t, "cluster-info.dump", testPods+testLogs)
+	tests := []struct {
+		pod       string
+		container string
+		want      string
+		notFound  bool
+	}{
+		{pod: "web", want: "web c\nweb d\n"},
+		{pod: "web", container: "d", want: "web d\n"},
+		{pod: "web-1", want: "web-1 c\n"},
+		{pod: "web-1", container: "c", want: "web-1 c\n"},
+		{pod: "we", notFound: true},
+		{pod: "web", container: "e", notFound: true},
+	}
+	// the first read scans the file, the second reads it through its index
+	for _, pass := range []string{"scanned", "indexed"} {
+		d := openDump(t, filePath)
+		for _, test := range tests {
+			r, err := d.Logs("default", test.pod, test.container)
+			if test.notFound {
+				if !errors.Is(err, ErrNotFound) {
+					t.Errorf("%s: Logs(%s, %s) error = %v, want ErrNotFound", pass, test.pod, test.container, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("%s: Logs(%s, %s) error: %v", pass, test.pod, test.container, err)
+			}
+			data, err := io.ReadAll(r)
+			r.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, line := range strings.SplitAfter(string(data), "\n") {
+				if len(line) > 0 && !strings.HasPrefix(line, "====") {
+					got = append(got, line)
+				}
+			}
+			if strings.Join(got, "") != test.want {
+				t.Errorf("%s: Logs(%s, %s) = %q, want %q", pass, test.pod, test.container, strings.Join(got, ""), test.want)
+			}
+		}
+	}
+	if _, err := os.Stat(filePath + indexFileName); err != nil {
+		t.Errorf("dump was not indexed: %v", err)
+	}
+}
+
+func TestIndexAfterAppend(t *testing.T) {
+	filePath := writeDump(t, "cluster-info.dump", testPods)
+	if got := names(t, openDump(t, filePath), "Pod"); !reflect.DeepEqual(got, []string{"web", "web-1"}) {
+		t.Fatalf("pods = %v", got)
+	}
+	if _, err := os.Stat(filePath + indexFileName); err != nil {
+		t.Fatalf("dump was not indexed: %v", err)
+	}
+	if got := names(t, openDump(t, filePath), "Pod"); !reflect.DeepEqual(got, []string{"web", "web-1"}) {
+		t.Fatalf("indexed pods = %v", got)
+	}
+
+	f, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"kind":"PodList","apiVersion":"v1","items":[{"metadata":{"name":"db","namespace":"default","uid":"3"}}]}` + "\n")
+	f.Close()
+	if got := names(t, openDump(t, filePath), "Pod"); !reflect.DeepEqual(got, []string{"web", "web-1", "db"}) {
+		t.Errorf("pods after append = %v", got)
+	}
+}
+
+func TestIndexKindlessItems(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "default"), 0755)
+	os.WriteFile(filepath.Join(dir, "nodes.json"), []byte(`{"kind":"NodeList","items":[]}`), 0644)
+	os.WriteFile(filepath.Join(dir, "default", "pods.json"), []byte(`{"kind":"List","items":[{"metadata":{"name":"web","namespace":"default"}}]}`), 0644)
+	for _, pass := range []string{"scanned", "indexed"} {
+		if got := names(t, openDump(t, dir), "Pod"); !reflect.DeepEqual(got, []string{"web"}) {
+			t.Errorf("%s: pods = %v", pass, got)
+		}
+	}
+}
+
+func TestObjectKinds(t *testing.T) {
+	filePath := writeDump(t, "cluster-info.dump", testPods+`{"kind":"WidgetList","apiVersion":"example.com/v1","items":[{"metadata":{"name":"w1"}}]}`+"\n")
+	want := []Kind{
+		{Kind: "Pod", Version: "v1", Namespaced: true},
+		{Kind: "Widget", Group: "example.com", Version: "v1"},
+	}
+	for _, pass := range []string{"scanned", "indexed"} {
+		got, err := openDump(t, filePath).ObjectKinds()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: ObjectKinds = %v, want %v", pass, got, want)
+		}
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	object := func(kind string, uid string, version string, source string) map[string]interface{} {
+		return map[string]interface{}{
+			"kind":     kind,
+			"metadata": map[string]interface{}{"uid": uid, "resourceVersion": version},
+			"source":   source,
+		}
+	}
+	tests := []struct {
+		name    string
+		objects []map[string]interface{}
+		want    []string
+	}{
+		{
+			name:    "newest resourceVersion wins",
+			objects: []map[string]interface{}{object("Pod", "1", "9", "a"), object("Pod", "1", "10", "b")},
+			want:    []string{"b"},
+		},
+		{
+			name:    "older copy is dropped",
+			objects: []map[string]interface{}{object("Pod", "1", "10", "a"), object("Pod", "1", "9", "b")},
+			want:    []string{"a"},
+		},
+		{
+			name:    "same UID of another kind is kept",
+			objects: []map[string]interface{}{object("Pod", "1", "1", "a"), object("Service", "1", "1", "b")},
+			want:    []string{"a", "b"},
+		},
+		{
+			name:    "objects without UID are kept",
+			objects: []map[string]interface{}{object("Pod", "", "1", "a"), object("Pod", "", "1", "b")},
+			want:    []string{"a", "b"},
+		},
+		{
+			name:    "order of first appearance",
+			objects: []map[string]interface{}{object("Pod", "1", "1", "a"), object("Pod", "2", "1", "b"), object("Pod", "1", "2", "c")},
+			want:    []string{"c", "b"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := []string{}
+			for _, obj := range dedupe(test.objects) {
+				got = append(got, obj["source"].(string))
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("dedupe = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDedupeAcrossDumps(t *testing.T) {
+	older := writeDump(t, "a.dump", `{"kind":"PodList","apiVersion":"v1","items":[{"metadata":{"name":"web","namespace":"default","uid":"1","resourceVersion":"5","labels":{"v":"old"}}}]}`)
+	newer := writeDump(t, "b.dump", `{"kind":"PodList","apiVersion":"v1","items":[{"metadata":{"name":"web","namespace":"default","uid":"1","resourceVersion":"7","labels":{"v":"new"}}}]}`)
+	objects, err := openDump(t, older, newer).List("Pod", "", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 1 || objects[0].GetLabels()["v"] != "new" || Source(objects[0].Object) != newer {
+		t.Errorf("objects = %v", objects)
+	}
+}
+
+func TestDetectLayout(t *testing.T) {
+	file := &fstest.MapFile{Data: []byte("{}")}
+	tests := []struct {
+		name   string
+		fsys   fstest.MapFS
+		root   string
+		layout layoutKind
+		path   string
+	}{
+		{
+			name:   "file",
+			fsys:   fstest.MapFS{"cluster-info.dump": file},
+			root:   "cluster-info.dump",
+			layout: layoutFile,
+			path:   "cluster-info.dump",
+		},
+		{
+			name:   "cluster-info directory",
+			fsys:   fstest.MapFS{"dump/nodes.json": file, "dump/default/pods.json": file},
+			root:   "dump",
+			layout: layoutClusterInfoDir,
+			path:   "dump",
+		},
+		{
+			name:   "namespaces only",
+			fsys:   fstest.MapFS{"dump/default/pods.yaml": file},
+			root:   "dump",
+			layout: layoutClusterInfoDir,
+			path:   "dump",
+		},
+		{
+			name:   "cluster-info file in a directory",
+			fsys:   fstest.MapFS{"dump/cluster-info.dump": file, "dump/README": file},
+			root:   "dump",
+			layout: layoutFile,
+			path:   "dump/cluster-info.dump",
+		},
+		{
+			name:   "nested sosreport",
+			fsys:   fstest.MapFS{"sos/sos_commands/kubernetes/_get_-o_json_nodes": file},
+			root:   "sos",
+			layout: layoutSosReport,
+			path:   "sos/sos_commands/kubernetes",
+		},
+		{
+			name:   "must-gather",
+			fsys:   fstest.MapFS{"mg/quay-io-image/namespaces/default/core/pods.yaml": file},
+			root:   "mg",
+			layout: layoutMustGather,
+			path:   "mg/quay-io-image",
+		},
+		{
+			name:   "index is ignored",
+			fsys:   fstest.MapFS{"dump/" + indexFileName: file, "dump/nodes.json": file},
+			root:   "dump",
+			layout: layoutClusterInfoDir,
+			path:   "dump",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			layout, root, err := detectLayout(test.fsys, test.root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if layout != test.layout || root != test.path {
+				t.Errorf("detectLayout = %v %s, want %v %s", layout, root, test.layout, test.path)
+			}
+		})
+	}
+}
@@ -0,0 +1,417 @@
+package dump
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	indexFileName = ".kubedmp-index"
+	indexVersion  = 3
+)
+
+// dumpIndex records where the objects and pod log sections of the files of a
+// dump are, so that later commands read only the bytes they need instead of
+// rescanning the dump. A file is indexed the first time it is scanned, and
+// indexed again when its size or modification time changes.
+type dumpIndex struct {
+	Version int
+	Files   map[string]*fileIndex
+	dirty   bool
+}
+
+type fileIndex struct {
+	Size    int64
+	ModTime int64
+	Objects []indexEntry
+	Logs    []logSection
+	// Newest is the newest timestamp in the file, see newestTimestamp.
+	Newest time.Time
+}
+
+type indexEntry struct {
+	Kind string
+	// APIVersion is the apiVersion of the list an item without one of its
+	// own was found in.
+	APIVersion string
+	Namespace  string
+	Name       string
+	Offset     int64
+	Length     int64
+}
+
+type indexedObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+}
+
+// loadIndex returns the index of the dump, reading it the first time, or nil
+// if the dump is not indexed.
+func (s *source) loadIndex() *dumpIndex {
+	if s.index != nil || len(s.indexPath) == 0 {
+		return s.index
+	}
+	s.index = &dumpIndex{Version: indexVersion, Files: map[string]*fileIndex{}}
+	f, err := os.Open(s.indexPath)
+	if err != nil {
+		return s.index
+	}
+	defer f.Close()
+	var index dumpIndex
+	if err := gob.NewDecoder(bufio.NewReader(f)).Decode(&index); err == nil && index.Version == indexVersion && index.Files != nil {
+		s.index = &index
+	}
+	return s.index
+}
+
+// saveIndex writes the index back if it changed. The index is only a cache,
+// so a dump in a read-only location is simply not indexed.
+func (s *source) saveIndex() {
+	if s.index == nil || !s.index.dirty {
+		return
+	}
+	s.index.dirty = false
+	f, err := os.CreateTemp(filepath.Dir(s.indexPath), filepath.Base(s.indexPath)+"-")
+	if err != nil {
+		return
+	}
+	f.Chmod(0644)
+	w := bufio.NewWriter(f)
+	err = gob.NewEncoder(w).Encode(s.index)
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.indexPath)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+}
+
+// lookupIndex returns the index of filePath if it is up to date.
+func (s *source) lookupIndex(filePath string) *fileIndex {
+	index := s.loadIndex()
+	if index == nil {
+		return nil
+	}
+	file, ok := index.Files[filePath]
+	if !ok {
+		return nil
+	}
+	info, err := fs.Stat(s.fsys, filePath)
+	if err != nil || info.Size() != file.Size || info.ModTime().UnixNano() != file.ModTime {
+		return nil
+	}
+	return file
+}
+
+// indexBuilder collects the index of a file while it is scanned.
+type indexBuilder struct {
+	file *fileIndex
+	// kind is the kind of the items of a generic List without one of their
+	// own, see docObjects.
+	kind string
+}
+
+// newIndexBuilder returns a builder for filePath, or nil if the file cannot
+// be read in place. kind is the kind of the objects the file holds, or empty
+// if it may hold any.
+func (s *source) newIndexBuilder(filePath string, compressed bool, kind string) *indexBuilder {
+	if s.loadIndex() == nil || compressed {
+		return nil
+	}
+	info, err := fs.Stat(s.fsys, filePath)
+	if err != nil {
+		return nil
+	}
+	return &indexBuilder{file: &fileIndex{Size: info.Size(), ModTime: info.ModTime().UnixNano()}, kind: kind}
+}
+
+func (b *indexBuilder) add(doc *dumpDoc) {
+	if b == nil || b.file == nil {
+		return
+	}
+	// Documents converted from YAML have no offsets to read them back from.
+	if doc.Offset < 0 {
+		b.file = nil
+		return
+	}
+	entries, err := indexDoc(doc, b.kind)
+	if err != nil {
+		return
+	}
+	b.file.Objects = append(b.file.Objects, entries...)
+}
+
+func (s *source) storeIndex(filePath string, b *indexBuilder, sections []logSection, newest time.Time) {
+	if b == nil || b.file == nil {
+		return
+	}
+	b.file.Logs = sections
+	b.file.Newest = newest
+	s.index.Files[filePath] = b.file
+	s.index.dirty = true
+}
+
+// canIndex reports whether filePath can be indexed.
+func (s *source) canIndex(filePath string) bool {
+	if s.loadIndex() == nil {
+		return false
+	}
+	f, compressed, err := s.openFile(filePath)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return !compressed
+}
+
+// buildIndex scans filePath without processing its documents. It indexes
+// the file if it can be read in place and records the newest timestamp in it.
+func (s *source) buildIndex(filePath string) *fileIndex {
+	f, compressed, err := s.openFile(filePath)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	builder := s.newIndexBuilder(filePath, compressed, "")
+	decoder := newDocDecoder(f)
+	newest := time.Time{}
+	for {
+		doc, err := decoder.Next()
+		if err == io.EOF {
+			break
+		}
+		if _, ok := err.(*regionError); ok {
+			continue
+		}
+		if err != nil {
+			return nil
+		}
+		builder.add(doc)
+		newest = laterTime(newest, newestTimestamp(doc.Data))
+	}
+	s.noteNewest(filePath, newest)
+	s.storeIndex(filePath, builder, decoder.sections, newest)
+	s.saveIndex()
+	if builder == nil {
+		return nil
+	}
+	return builder.file
+}
+
+// indexDoc returns the objects of a document with their offsets: the items
+// of a list, or the document itself if it holds a single object. Items
+// without a kind are given one as docObjects does, the kind of a typed list
+// or else defaultKind.
+func indexDoc(doc *dumpDoc, defaultKind string) ([]indexEntry, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc.Data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	kind := ""
+	apiVersion := ""
+	var object indexedObject
+	var entries []indexEntry
+	hasItems := false
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "apiVersion":
+			err = dec.Decode(&apiVersion)
+		case "kind":
+			err = dec.Decode(&kind)
+		case "metadata":
+			err = dec.Decode(&object.Metadata)
+		case "items":
+			hasItems = true
+			entries, err = indexItems(dec, doc.Offset)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !hasItems {
+		if len(kind) == 0 || strings.HasSuffix(kind, "List") {
+			return nil, nil
+		}
+		return []indexEntry{{Kind: kind, APIVersion: apiVersion, Namespace: object.Metadata.Namespace, Name: object.Metadata.Name, Offset: doc.Offset, Length: int64(len(doc.Data))}}, nil
+	}
+	// Items of a typed list usually have no kind or apiVersion of their own.
+	if kind != "List" {
+		defaultKind = strings.TrimSuffix(kind, "List")
+	}
+	for i := range entries {
+		if len(entries[i].Kind) == 0 {
+			entries[i].Kind = defaultKind
+		}
+		if len(entries[i].APIVersion) == 0 {
+			entries[i].APIVersion = apiVersion
+		}
+	}
+	return entries, nil
+}
+
+func indexItems(dec *json.Decoder, base int64) ([]indexEntry, error) {
+	entries := []indexEntry{}
+	token, err := dec.Token()
+	if err != nil || token == nil {
+		return entries, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("items is not a list")
+	}
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		var object indexedObject
+		if err := json.Unmarshal(raw, &object); err != nil {
+			continue
+		}
+		end := base + dec.InputOffset()
+		entries = append(entries, indexEntry{
+			Kind:       object.Kind,
+			APIVersion: object.APIVersion,
+			Namespace:  object.Metadata.Namespace,
+			Name:       object.Metadata.Name,
+			Offset:     end - int64(len(raw)),
+			Length:     int64(len(raw)),
+		})
+	}
+	_, err = dec.Token()
+	return entries, err
+}
+
+// readIndexed returns the objects in filePath that may match f, read from
+// the offsets in its index. Objects indexed without a kind are of kind, as
+// in read. It returns false if the file cannot be read in place.
+func (s *source) readIndexed(filePath string, file *fileIndex, f filter, kind string) ([]map[string]interface{}, bool) {
+	r, err := s.fsys.Open(filePath)
+	if err != nil {
+		return nil, false
+	}
+	defer r.Close()
+	reader, ok := r.(io.ReaderAt)
+	if !ok {
+		return nil, false
+	}
+	objects := []map[string]interface{}{}
+	for _, entry := range file.Objects {
+		if len(entry.Kind) == 0 {
+			entry.Kind = kind
+		}
+		if !f.matchesEntry(entry) {
+			continue
+		}
+		data := make([]byte, entry.Length)
+		if _, err := reader.ReadAt(data, entry.Offset); err != nil {
+			return nil, false
+		}
+		var obj map[string]interface{}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			s.dump.Warnf("Warning: skipped object at %s: %v", s.location(filePath), err)
+			continue
+		}
+		setKind(obj, entry.Kind, entry.APIVersion)
+		objects = append(objects, obj)
+	}
+	return objects, true
+}
+
+// objectKinds returns the kind, apiVersion and namespace of every object in
+// the files of the dump, from their index where possible.
+func (s *source) objectKinds() ([]indexEntry, error) {
+	entries := []indexEntry{}
+	kinds := []string{""}
+	if s.layout != layoutFile {
+		kinds = kinds[:0]
+		for _, k := range s.dump.Kinds() {
+			kinds = append(kinds, k.Kind)
+		}
+	}
+	for _, kind := range kinds {
+		filePaths, err := s.kindFiles(kind, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, filePath := range filePaths {
+			file := s.lookupIndex(filePath)
+			if file == nil && s.canIndex(filePath) {
+				file = s.buildIndex(filePath)
+			}
+			if file != nil {
+				for _, entry := range file.Objects {
+					if len(entry.Kind) == 0 {
+						entry.Kind = kind
+					}
+					entries = append(entries, entry)
+				}
+				continue
+			}
+			objects, err := s.read([]string{filePath}, filter{}, kind)
+			if err != nil {
+				return nil, err
+			}
+			for _, obj := range objects {
+				u := unstructured.Unstructured{Object: obj}
+				entries = append(entries, indexEntry{Kind: u.GetKind(), APIVersion: u.GetAPIVersion(), Namespace: u.GetNamespace()})
+			}
+		}
+	}
+	return entries, nil
+}
+
+// indexedLogs returns the log sections of file whose START line matches
+// marker, read in place. It returns false if the file cannot be read in
+// place.
+func (s *source) indexedLogs(filePath string, file *fileIndex, marker string) (io.ReadCloser, bool) {
+	f, err := s.fsys.Open(filePath)
+	if err != nil {
+		return nil, false
+	}
+	reader, ok := f.(io.ReaderAt)
+	if !ok {
+		f.Close()
+		return nil, false
+	}
+	readers := []io.Reader{}
+	for _, section := range file.Logs {
+		if !matchesMarker(section.Marker, marker) {
+			continue
+		}
+		readers = append(readers, io.NewSectionReader(reader, section.Offset, section.Length))
+		// A section cut off by the end of the file has no final newline.
+		last := make([]byte, 1)
+		if _, err := reader.ReadAt(last, section.Offset+section.Length-1); err == nil && last[0] != '\n' {
+			readers = append(readers, strings.NewReader("\n"))
+		}
+	}
+	return &readCloser{Reader: io.MultiReader(readers...), close: f.Close}, true
+}
@@ -0,0 +1,148 @@
+package dump
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// Kind describes a resource type a dump directory is read for and where its
+// objects are kept in the layouts that split a dump into files.
+type Kind struct {
+	Kind    string
+	Group   string
+	Version string
+	// Resource is the plural, lower case name of the kind, such as "pods".
+	Resource   string
+	Namespaced bool
+	// FileName is the name, without extension, of the file cluster-info dump
+	// and kubedmp dump write the kind to.
+	FileName string
+
+	// Singular and ShortNames are the other names of the kind on the command
+	// line, such as "pod" and "po". PrinterColumns are only set for the kinds
+	// defined by a CustomResourceDefinition in the dump, and for the known
+	// kinds that are custom resources, such as VolumeSnapshot.
+	Singular       string
+	ShortNames     []string
+	PrinterColumns []PrinterColumn
+}
+
+// PrinterColumn is an additionalPrinterColumn of a CustomResourceDefinition.
+type PrinterColumn struct {
+	Name     string
+	Type     string
+	JSONPath string
+	Priority int64
+}
+
+// GroupVersionKind returns the group, version and kind of k.
+func (k Kind) GroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: k.Group, Version: k.Version, Kind: k.Kind}
+}
+
+// APIVersion returns the apiVersion objects of k are written with, such as
+// "apps/v1".
+func (k Kind) APIVersion() string {
+	return k.GroupVersionKind().GroupVersion().String()
+}
+
+// mustGatherPath returns the <group>/<resource> path must-gather writes the
+// kind under.
+func (k Kind) mustGatherPath() string {
+	group := k.Group
+	if len(group) == 0 {
+		group = "core"
+	}
+	return group + "/" + k.Resource
+}
+
+// kinds lists the kinds a dump directory is read for, in the order List
+// reads them when no kind is given.
+var kinds = []Kind{
+	{Kind: "Namespace", Version: "v1", Resource: "namespaces", FileName: "namespaces", Singular: "namespace", ShortNames: []string{"ns"}},
+	{Kind: "Node", Version: "v1", Resource: "nodes", FileName: "nodes", Singular: "node", ShortNames: []string{"no"}},
+	{Kind: "PersistentVolume", Version: "v1", Resource: "persistentvolumes", FileName: "pv", Singular: "persistentvolume", ShortNames: []string{"pv"}},
+	{Kind: "StorageClass", Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses", FileName: "sc", Singular: "storageclass", ShortNames: []string{"sc"}},
+	{Kind: "ClusterRole", Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles", FileName: "clusterroles", Singular: "clusterrole"},
+	{Kind: "ClusterRoleBinding", Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterrolebindings", FileName: "clusterrolebindings", Singular: "clusterrolebinding"},
+	{Kind: "IngressClass", Group: "networking.k8s.io", Version: "v1", Resource: "ingressclasses", FileName: "ingressclasses", Singular: "ingressclass"},
+	{Kind: "PriorityClass", Group: "scheduling.k8s.io", Version: "v1", Resource: "priorityclasses", FileName: "priorityclasses", Singular: "priorityclass", ShortNames: []string{"pc"}},
+	{Kind: "CSIDriver", Group: "storage.k8s.io", Version: "v1", Resource: "csidrivers", FileName: "csidrivers", Singular: "csidriver"},
+	{Kind: "CSINode", Group: "storage.k8s.io", Version: "v1", Resource: "csinodes", FileName: "csinodes", Singular: "csinode"},
+	{Kind: "VolumeAttachment", Group: "storage.k8s.io", Version: "v1", Resource: "volumeattachments", FileName: "volumeattachments", Singular: "volumeattachment"},
+	{Kind: "VolumeSnapshotContent", Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshotcontents", FileName: "volumesnapshotcontents", Singular: "volumesnapshotcontent", ShortNames: []string{"vsc", "vscs"},
+		PrinterColumns: []PrinterColumn{
+			{Name: "ReadyToUse", Type: "boolean", JSONPath: ".status.readyToUse"},
+			{Name: "RestoreSize", Type: "integer", JSONPath: ".status.restoreSize"},
+			{Name: "DeletionPolicy", Type: "string", JSONPath: ".spec.deletionPolicy"},
+			{Name: "Driver", Type: "string", JSONPath: ".spec.driver"},
+			{Name: "VolumeSnapshotClass", Type: "string", JSONPath: ".spec.volumeSnapshotClassName"},
+			{Name: "VolumeSnapshot", Type: "string", JSONPath: ".spec.volumeSnapshotRef.name"},
+			{Name: "VolumeSnapshotNamespace", Type: "string", JSONPath: ".spec.volumeSnapshotRef.namespace"},
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		}},
+	{Kind: "ValidatingWebhookConfiguration", Group: "admissionregistration.k8s.io", Version: "v1", Resource: "validatingwebhookconfigurations", FileName: "validatingwebhookconfigurations", Singular: "validatingwebhookconfiguration"},
+	{Kind: "MutatingWebhookConfiguration", Group: "admissionregistration.k8s.io", Version: "v1", Resource: "mutatingwebhookconfigurations", FileName: "mutatingwebhookconfigurations", Singular: "mutatingwebhookconfiguration"},
+	{Kind: "APIService", Group: "apiregistration.k8s.io", Version: "v1", Resource: "apiservices", FileName: "apiservices", Singular: "apiservice"},
+	{Kind: "Event", Version: "v1", Resource: "events", Namespaced: true, FileName: "events", Singular: "event"},
+	{Kind: "Service", Version: "v1", Resource: "services", Namespaced: true, FileName: "services", Singular: "service", ShortNames: []string{"svc"}},
+	{Kind: "DaemonSet", Group: "apps", Version: "v1", Resource: "daemonsets", Namespaced: true, FileName: "daemonsets", Singular: "daemonset", ShortNames: []string{"ds"}},
+	{Kind: "Deployment", Group: "apps", Version: "v1", Resource: "deployments", Namespaced: true, FileName: "deployments", Singular: "deployment", ShortNames: []string{"deploy"}},
+	{Kind: "ReplicaSet", Group: "apps", Version: "v1", Resource: "replicasets", Namespaced: true, FileName: "replicasets", Singular: "replicaset", ShortNames: []string{"rs"}},
+	{Kind: "StatefulSet", Group: "apps", Version: "v1", Resource: "statefulsets", Namespaced: true, FileName: "statefulsets", Singular: "statefulset", ShortNames: []string{"sts"}},
+	{Kind: "Pod", Version: "v1", Resource: "pods", Namespaced: true, FileName: "pods", Singular: "pod", ShortNames: []string{"po"}},
+	{Kind: "PersistentVolumeClaim", Version: "v1", Resource: "persistentvolumeclaims", Namespaced: true, FileName: "pvc", Singular: "persistentvolumeclaim", ShortNames: []string{"pvc"}},
+	{Kind: "ConfigMap", Version: "v1", Resource: "configmaps", Namespaced: true, FileName: "configmaps", Singular: "configmap", ShortNames: []string{"cm"}},
+	{Kind: "Secret", Version: "v1", Resource: "secrets", Namespaced: true, FileName: "secrets", Singular: "secret"},
+	{Kind: "ServiceAccount", Version: "v1", Resource: "serviceaccounts", Namespaced: true, FileName: "serviceaccounts", Singular: "serviceaccount", ShortNames: []string{"sa"}},
+	{Kind: "Ingress", Group: "networking.k8s.io", Version: "v1", Resource: "ingresses", Namespaced: true, FileName: "ingresses", Singular: "ingress", ShortNames: []string{"ing"}},
+	{Kind: "Endpoints", Version: "v1", Resource: "endpoints", Namespaced: true, FileName: "endpoints", Singular: "endpoint", ShortNames: []string{"ep"}},
+	{Kind: "EndpointSlice", Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices", Namespaced: true, FileName: "endpointslices", Singular: "endpointslice"},
+	{Kind: "NetworkPolicy", Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies", Namespaced: true, FileName: "networkpolicies", Singular: "networkpolicy", ShortNames: []string{"netpol"}},
+	{Kind: "Job", Group: "batch", Version: "v1", Resource: "jobs", Namespaced: true, FileName: "jobs", Singular: "job"},
+	{Kind: "CronJob", Group: "batch", Version: "v1", Resource: "cronjobs", Namespaced: true, FileName: "cronjobs", Singular: "cronjob", ShortNames: []string{"cj"}},
+	{Kind: "VolumeSnapshot", Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshots", Namespaced: true, FileName: "volumesnapshots", Singular: "volumesnapshot", ShortNames: []string{"vs"},
+		PrinterColumns: []PrinterColumn{
+			{Name: "ReadyToUse", Type: "boolean", JSONPath: ".status.readyToUse"},
+			{Name: "SourcePVC", Type: "string", JSONPath: ".spec.source.persistentVolumeClaimName"},
+			{Name: "SourceSnapshotContent", Type: "string", JSONPath: ".spec.source.volumeSnapshotContentName"},
+			{Name: "RestoreSize", Type: "string", JSONPath: ".status.restoreSize"},
+			{Name: "SnapshotClass", Type: "string", JSONPath: ".spec.volumeSnapshotClassName"},
+			{Name: "SnapshotContent", Type: "string", JSONPath: ".status.boundVolumeSnapshotContentName"},
+			{Name: "CreationTime", Type: "date", JSONPath: ".status.creationTime"},
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		}},
+	{Kind: "Role", Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "roles", Namespaced: true, FileName: "roles", Singular: "role"},
+	{Kind: "RoleBinding", Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "rolebindings", Namespaced: true, FileName: "rolebindings", Singular: "rolebinding"},
+	{Kind: "ResourceQuota", Version: "v1", Resource: "resourcequotas", Namespaced: true, FileName: "resourcequotas", Singular: "resourcequota", ShortNames: []string{"quota"}},
+	{Kind: "LimitRange", Version: "v1", Resource: "limitranges", Namespaced: true, FileName: "limitranges", Singular: "limitrange", ShortNames: []string{"limits"}},
+	{Kind: "HorizontalPodAutoscaler", Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers", Namespaced: true, FileName: "hpa", Singular: "horizontalpodautoscaler", ShortNames: []string{"hpa"}},
+	{Kind: "PodDisruptionBudget", Group: "policy", Version: "v1", Resource: "poddisruptionbudgets", Namespaced: true, FileName: "pdb", Singular: "poddisruptionbudget", ShortNames: []string{"pdb"}},
+	{Kind: "CustomResourceDefinition", Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions", FileName: "crds", Singular: "customresourcedefinition", ShortNames: []string{"crd", "crds"}},
+}
+
+// Kinds returns the kinds kubedmp knows, see also Dump.Kinds.
+func Kinds() []Kind {
+	return append([]Kind{}, kinds...)
+}
+
+// LookupKind returns the known Kind named kind, such as "Pod".
+func LookupKind(kind string) (Kind, bool) {
+	for _, k := range kinds {
+		if k.Kind == kind {
+			return k, true
+		}
+	}
+	return Kind{}, false
+}
+
+// Namespaced reports whether objects of kind live in a namespace. Kinds
+// kubedmp does not know are taken to be namespaced.
+func Namespaced(kind string) bool {
+	k, ok := LookupKind(kind)
+	return !ok || k.Namespaced
+}
+
+// FileName returns the name, without extension, of the file the objects of
+// kind are written to in a dump directory, such as "pods".
+func FileName(kind string) string {
+	k, _ := LookupKind(kind)
+	return k.FileName
+}
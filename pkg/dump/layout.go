@@ -0,0 +1,331 @@
+package dump
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// layoutKind identifies how the objects of a dump are laid out on disk.
+type layoutKind int
+
+const (
+	// layoutFile is a single file written by cluster-info dump.
+	layoutFile layoutKind = iota
+	// layoutClusterInfoDir is a directory written by cluster-info dump or
+	// kubedmp dump with --output-directory.
+	layoutClusterInfoDir
+	// layoutSosReport is the kubernetes plugin directory of a sosreport.
+	layoutSosReport
+	// layoutMustGather is a directory written by oc adm must-gather.
+	layoutMustGather
+)
+
+var dumpFileExtensions = []string{".json", ".yaml", ".yml"}
+
+const (
+	sosGetPrefix   = "_get_-o_json_"
+	maxDetectDepth = 5
+
+	mustGatherClusterDir   = "cluster-scoped-resources"
+	mustGatherNamespaceDir = "namespaces"
+)
+
+func (l layoutKind) String() string {
+	switch l {
+	case layoutFile:
+		return "cluster-info file"
+	case layoutClusterInfoDir:
+		return "cluster-info directory"
+	case layoutSosReport:
+		return "sosreport"
+	case layoutMustGather:
+		return "must-gather"
+	}
+	return "unknown"
+}
+
+// detectLayout inspects the contents of root and returns the layout of the
+// dump and the path the layout is rooted at. Directories are searched
+// breadth first, so a dump nested in a renamed or copied tree, such as the
+// kubernetes plugin of an extracted sosreport, is still found.
+func detectLayout(fsys fs.FS, root string) (layoutKind, string, error) {
+	info, err := fs.Stat(fsys, root)
+	if err != nil {
+		return layoutFile, root, err
+	}
+	if !info.IsDir() {
+		return layoutFile, root, nil
+	}
+	queue := []string{root}
+	for depth := 0; depth < maxDetectDepth && len(queue) > 0; depth++ {
+		var next []string
+		for _, dir := range queue {
+			entries, err := fs.ReadDir(fsys, dir)
+			if err != nil {
+				continue
+			}
+			if layout, dumpPath, ok := layoutOf(fsys, dir, entries); ok {
+				return layout, dumpPath, nil
+			}
+			for _, entry := range entries {
+				if entry.IsDir() {
+					next = append(next, path.Join(dir, entry.Name()))
+				}
+			}
+		}
+		queue = next
+	}
+	return layoutClusterInfoDir, root, nil
+}
+
+// layoutOf recognises dir as the root of a dump by its entries.
+func layoutOf(fsys fs.FS, dir string, entries []fs.DirEntry) (layoutKind, string, bool) {
+	dumpFile := ""
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			if name == mustGatherClusterDir || name == mustGatherNamespaceDir {
+				return layoutMustGather, dir, true
+			}
+			continue
+		}
+		if strings.Contains(name, indexFileName) {
+			continue
+		}
+		if strings.Contains(name, sosGetPrefix) {
+			return layoutSosReport, dir, true
+		}
+		if isDumpFile(name, "nodes") {
+			return layoutClusterInfoDir, dir, true
+		}
+		if strings.HasPrefix(name, "cluster-info") {
+			dumpFile = path.Join(dir, name)
+		}
+	}
+	// Namespace-only dumps have nothing at the top level.
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		children, err := fs.ReadDir(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		for _, child := range children {
+			if child.IsDir() {
+				continue
+			}
+			if strings.Contains(child.Name(), sosGetPrefix+"--namespace_") {
+				return layoutSosReport, dir, true
+			}
+			if isDumpFile(child.Name(), "pods") {
+				return layoutClusterInfoDir, dir, true
+			}
+		}
+	}
+	if len(dumpFile) > 0 {
+		return layoutFile, dumpFile, true
+	}
+	return layoutFile, "", false
+}
+
+func isDumpFile(fileName string, name string) bool {
+	return strings.HasPrefix(fileName, name) && isDumpExtension(fileName[len(name):])
+}
+
+func isDumpExtension(ext string) bool {
+	for _, dumpExt := range dumpFileExtensions {
+		if ext == dumpExt {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaces returns the namespaces that have a directory in the dump.
+func (s *source) namespaces() ([]string, error) {
+	namespaces := []string{}
+	if s.layout == layoutFile {
+		return namespaces, nil
+	}
+	dir := s.root
+	if s.layout == layoutMustGather {
+		dir = path.Join(s.root, mustGatherNamespaceDir)
+		if _, err := fs.Stat(s.fsys, dir); err != nil {
+			return namespaces, nil
+		}
+	}
+	entries, err := fs.ReadDir(s.fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			namespaces = append(namespaces, entry.Name())
+		}
+	}
+	return namespaces, nil
+}
+
+// kindFiles returns the files of the dump that hold objects of kind in
+// namespace, or in every namespace when namespace is empty. Files that do not
+// exist are included; readers skip them.
+func (s *source) kindFiles(kind string, namespace string) ([]string, error) {
+	files := []string{}
+	if s.layout == layoutFile {
+		return append(files, s.root), nil
+	}
+	k, ok := s.dump.LookupKind(kind)
+	if !ok {
+		return files, nil
+	}
+	namespaces := []string{namespace}
+	if k.Namespaced && len(namespace) == 0 {
+		var err error
+		if namespaces, err = s.namespaces(); err != nil {
+			return nil, err
+		}
+	}
+	switch s.layout {
+	case layoutClusterInfoDir:
+		if !k.Namespaced {
+			files = append(files, s.filePath(s.root, k.FileName))
+			break
+		}
+		for _, ns := range namespaces {
+			files = append(files, s.filePath(path.Join(s.root, ns), k.FileName))
+		}
+	case layoutSosReport:
+		if !k.Namespaced {
+			files = append(files, s.sosFiles(s.root, sosGetPrefix+k.FileName)...)
+			break
+		}
+		for _, ns := range namespaces {
+			files = append(files, s.sosFiles(path.Join(s.root, ns), sosGetPrefix+"--namespace_"+ns+"_"+k.FileName)...)
+		}
+	case layoutMustGather:
+		if k.Kind == "Namespace" {
+			// must-gather writes each Namespace next to the objects in it
+			all, err := s.namespaces()
+			if err != nil {
+				return nil, err
+			}
+			for _, ns := range all {
+				files = append(files, s.filePath(path.Join(s.root, mustGatherNamespaceDir, ns), ns))
+			}
+		}
+		if !k.Namespaced {
+			files = append(files, s.mustGatherFiles(path.Join(s.root, mustGatherClusterDir), k.mustGatherPath())...)
+			break
+		}
+		for _, ns := range namespaces {
+			files = append(files, s.mustGatherFiles(path.Join(s.root, mustGatherNamespaceDir, ns), k.mustGatherPath())...)
+		}
+	}
+	return files, nil
+}
+
+// mustGatherFiles returns the files in dir holding resource, which is either
+// a list in <group>/<resource>.yaml or one object per file in
+// <group>/<resource>/. Pods without a list are read from the pods/<pod>/
+// directories that also hold their logs.
+func (s *source) mustGatherFiles(dir string, resource string) []string {
+	listFile := s.filePath(path.Join(dir, path.Dir(resource)), path.Base(resource))
+	if _, err := fs.Stat(s.fsys, listFile); err == nil {
+		return []string{listFile}
+	}
+	files := []string{}
+	entries, _ := fs.ReadDir(s.fsys, path.Join(dir, resource))
+	for _, entry := range entries {
+		if !entry.IsDir() && isDumpExtension(path.Ext(entry.Name())) {
+			files = append(files, path.Join(dir, resource, entry.Name()))
+		}
+	}
+	if pods, _ := LookupKind("Pod"); resource != pods.mustGatherPath() {
+		return files
+	}
+	podDirs, _ := fs.ReadDir(s.fsys, path.Join(dir, "pods"))
+	for _, podDir := range podDirs {
+		if !podDir.IsDir() {
+			continue
+		}
+		podFile := s.filePath(path.Join(dir, "pods", podDir.Name()), podDir.Name())
+		if _, err := fs.Stat(s.fsys, podFile); err == nil {
+			files = append(files, podFile)
+		}
+	}
+	return files
+}
+
+// sosFiles returns the files in dir whose names end with suffix.
+func (s *source) sosFiles(dir string, suffix string) []string {
+	files := []string{}
+	entries, err := fs.ReadDir(s.fsys, dir)
+	if err != nil {
+		return files
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), suffix) {
+			files = append(files, path.Join(dir, entry.Name()))
+		}
+	}
+	return files
+}
+
+// podLogFile returns the file holding the logs of a pod and the marker of
+// its log sections, see matchesMarker, which is empty when the file holds
+// nothing else. The file is empty when the dump has no logs of the pod.
+func (s *source) podLogFile(namespace string, pod string, container string) (string, string, error) {
+	marker := " of pod " + namespace + "/" + pod + " ===="
+	if len(container) > 0 {
+		marker = " for container " + container + marker
+	}
+	switch s.layout {
+	case layoutFile:
+		return s.root, marker, nil
+	case layoutClusterInfoDir:
+		return path.Join(s.root, namespace, pod, "logs.txt"), marker, nil
+	case layoutSosReport:
+		podDir := path.Join(s.root, namespace, "podlogs", pod)
+		podFiles, err := fs.ReadDir(s.fsys, podDir)
+		if err != nil {
+			return "", "", nil
+		}
+		if len(container) == 0 && len(podFiles) > 1 {
+			return "", "", fmt.Errorf("pod %s/%s has more than one container, a container name is needed", namespace, pod)
+		}
+		for _, podFile := range podFiles {
+			if podFile.IsDir() {
+				continue
+			}
+			if len(container) == 0 || strings.HasSuffix(podFile.Name(), "_--namespace_"+namespace+"_logs_"+pod+"_-c_"+container) {
+				return path.Join(podDir, podFile.Name()), "", nil
+			}
+		}
+	case layoutMustGather:
+		podDir := path.Join(s.root, mustGatherNamespaceDir, namespace, "pods", pod)
+		if len(container) == 0 {
+			entries, err := fs.ReadDir(s.fsys, podDir)
+			if err != nil {
+				return "", "", nil
+			}
+			containers := []string{}
+			for _, entry := range entries {
+				if entry.IsDir() {
+					containers = append(containers, entry.Name())
+				}
+			}
+			if len(containers) > 1 {
+				return "", "", fmt.Errorf("pod %s/%s has more than one container, a container name is needed", namespace, pod)
+			}
+			if len(containers) == 0 {
+				return "", "", nil
+			}
+			container = containers[0]
+		}
+		return path.Join(podDir, container, container, "logs", "current.log"), "", nil
+	}
+	return "", "", nil
+}
@@ -0,0 +1,78 @@
+package dump
+
+import "sort"
+
+// discoveredNamespaces returns a Namespace for each namespace of the dump
+// that matches f and is not among the Namespace objects in found. kubectl
+// cluster-info dump does not write Namespace objects, so their names are
+// taken from the namespace directories of a dump directory, or from the
+// objects in a dump file. Such a Namespace holds only its name and is marked
+// with SynthesizedKey.
+func (d *Dump) discoveredNamespaces(found []map[string]interface{}, f filter) ([]map[string]interface{}, error) {
+	seen := map[string]bool{}
+	for _, obj := range found {
+		if kind, _ := obj["kind"].(string); kind == "Namespace" {
+			metadata, _ := obj["metadata"].(map[string]interface{})
+			name, _ := metadata["name"].(string)
+			seen[name] = true
+		}
+	}
+	namespaces := []map[string]interface{}{}
+	for _, s := range d.sources {
+		names, err := s.namespaceNames()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			ns := map[string]interface{}{
+				"apiVersion":   "v1",
+				"kind":         "Namespace",
+				"metadata":     map[string]interface{}{"name": name},
+				SynthesizedKey: true,
+			}
+			if f.matches(ns) {
+				ns[SourceKey] = s.name
+				namespaces = append(namespaces, ns)
+			}
+		}
+	}
+	return namespaces, nil
+}
+
+// namespaceNames returns the sorted names of the namespaces s has objects
+// in. A dump file is read through its index when it has one.
+func (s *source) namespaceNames() ([]string, error) {
+	if s.layout != layoutFile {
+		names, err := s.namespaces()
+		sort.Strings(names)
+		return names, err
+	}
+	set := map[string]bool{}
+	if file := s.lookupIndex(s.root); file != nil {
+		for _, entry := range file.Objects {
+			set[entry.Namespace] = true
+		}
+	} else {
+		objects, err := s.list(filter{})
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objects {
+			metadata, _ := obj["metadata"].(map[string]interface{})
+			namespace, _ := metadata["namespace"].(string)
+			set[namespace] = true
+		}
+	}
+	names := []string{}
+	for name := range set {
+		if len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,376 @@
+package dump
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// filter selects the objects a read returns. Empty fields match anything.
+type filter struct {
+	kinds     []string
+	namespace string
+	name      string
+	selector  labels.Selector
+	fields    fields.Selector
+}
+
+// matchesEntry reports whether an object in the index may match f. Objects
+// without a namespace are cluster scoped and match any namespace.
+func (f filter) matchesEntry(entry indexEntry) bool {
+	if len(f.kinds) > 0 && !containsKind(f.kinds, entry.Kind) {
+		return false
+	}
+	if len(f.name) > 0 && entry.Name != f.name {
+		return false
+	}
+	if len(f.namespace) > 0 && len(entry.Namespace) > 0 && entry.Namespace != f.namespace {
+		return false
+	}
+	return true
+}
+
+func (f filter) matches(obj map[string]interface{}) bool {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	kind, _ := obj["kind"].(string)
+	name, _ := metadata["name"].(string)
+	namespace, _ := metadata["namespace"].(string)
+	if !f.matchesEntry(indexEntry{Kind: kind, Namespace: namespace, Name: name}) {
+		return false
+	}
+	if f.selector != nil && !f.selector.Empty() {
+		objLabels := labels.Set{}
+		itemLabels, _ := metadata["labels"].(map[string]interface{})
+		for k, v := range itemLabels {
+			objLabels[k], _ = v.(string)
+		}
+		if !f.selector.Matches(objLabels) {
+			return false
+		}
+	}
+	if f.fields != nil && !f.fields.Empty() {
+		objFields := fields.Set{}
+		for _, requirement := range f.fields.Requirements() {
+			objFields[requirement.Field] = FieldValue(obj, requirement.Field)
+		}
+		if !f.fields.Matches(objFields) {
+			return false
+		}
+	}
+	return true
+}
+
+// FieldValue returns the field at path, such as "status.phase", of obj as a
+// string, or "" if it is missing or not a value. Field selectors are matched
+// against it.
+func FieldValue(obj map[string]interface{}, path string) string {
+	var value interface{} = obj
+	for _, field := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = m[field]
+	}
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	return ""
+}
+
+func containsKind(kinds []string, kind string) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// list returns the objects of the dump that match f. A single file is read
+// once for all the kinds of f; a directory is read for each kind.
+func (s *source) list(f filter) ([]map[string]interface{}, error) {
+	if s.layout == layoutFile {
+		kind := ""
+		if len(f.kinds) == 1 {
+			kind = f.kinds[0]
+		}
+		return s.read([]string{s.root}, f, kind)
+	}
+	kinds := f.kinds
+	if len(kinds) == 0 {
+		for _, k := range s.dump.Kinds() {
+			kinds = append(kinds, k.Kind)
+		}
+	}
+	objects := []map[string]interface{}{}
+	for _, kind := range kinds {
+		filePaths, err := s.kindFiles(kind, f.namespace)
+		if err != nil {
+			return nil, err
+		}
+		found, err := s.read(filePaths, f, kind)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, found...)
+	}
+	return objects, nil
+}
+
+// read decodes every JSON or YAML document in filePaths and returns the
+// objects in them that match f, both the items of lists and objects written
+// one per document, as must-gather does. Items of a generic List without a
+// kind of their own are taken to be of kind. Unparsable regions and
+// documents are reported through Warnf with their location instead of being
+// dropped silently. Files are indexed as they are scanned; once indexed,
+// only the objects f may match are read from them.
+func (s *source) read(filePaths []string, f filter, kind string) ([]map[string]interface{}, error) {
+	objects := []map[string]interface{}{}
+	add := func(obj map[string]interface{}) {
+		if f.matches(obj) {
+			obj[SourceKey] = s.name
+			objects = append(objects, obj)
+		}
+	}
+	defer s.saveIndex()
+	for _, filePath := range filePaths {
+		if len(f.kinds) > 0 {
+			if file := s.lookupIndex(filePath); file != nil {
+				if found, ok := s.readIndexed(filePath, file, f, kind); ok {
+					for _, obj := range found {
+						add(obj)
+					}
+					s.noteNewest(filePath, file.Newest)
+					continue
+				}
+			}
+		}
+		r, compressed, err := s.openFile(filePath)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", s.location(filePath), err)
+		}
+
+		// A dump file may hold any kind, while kind is only the one asked
+		// for; the files of a dump directory hold a single kind.
+		fileKind := kind
+		if s.layout == layoutFile {
+			fileKind = ""
+		}
+		builder := s.newIndexBuilder(filePath, compressed, fileKind)
+		decoder := newDocDecoder(r)
+		newest := time.Time{}
+		for {
+			doc, err := decoder.Next()
+			if err == io.EOF {
+				break
+			}
+			if region, ok := err.(*regionError); ok {
+				s.dump.Warnf("Warning: skipped unparsable content in %s, %v", s.location(filePath), region)
+				continue
+			}
+			if err != nil {
+				r.Close()
+				return nil, fmt.Errorf("reading %s: %w", s.location(filePath), err)
+			}
+			builder.add(doc)
+			newest = laterTime(newest, newestTimestamp(doc.Data))
+			found, err := docObjects(doc.Data, kind)
+			if err != nil {
+				s.dump.Warnf("Warning: skipped document at %s:%d: %v", s.location(filePath), doc.Line, err)
+				continue
+			}
+			for _, obj := range found {
+				add(obj)
+			}
+		}
+		r.Close()
+		s.noteNewest(filePath, newest)
+		s.storeIndex(filePath, builder, decoder.sections, newest)
+	}
+	return objects, nil
+}
+
+// docObjects returns the objects in a document: the items of a list, or the
+// document itself if it holds a single object. Items get the kind and
+// apiVersion of their list if they have none of their own.
+func docObjects(data []byte, kind string) ([]map[string]interface{}, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	docKind, _ := doc["kind"].(string)
+	apiVersion, _ := doc["apiVersion"].(string)
+	items, ok := doc["items"]
+	if !ok {
+		if _, ok := doc["metadata"]; !ok || len(docKind) == 0 || strings.HasSuffix(docKind, "List") {
+			return nil, nil
+		}
+		return []map[string]interface{}{doc}, nil
+	}
+	list, ok := items.([]interface{})
+	if !ok {
+		if items == nil {
+			return nil, nil
+		}
+		return nil, errors.New("items is not a list")
+	}
+	if docKind != "List" {
+		kind = strings.TrimSuffix(docKind, "List")
+	}
+	objects := []map[string]interface{}{}
+	for _, item := range list {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		setKind(obj, kind, apiVersion)
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// setKind sets the kind and apiVersion of obj if it has none.
+func setKind(obj map[string]interface{}, kind string, apiVersion string) {
+	if k, _ := obj["kind"].(string); len(k) == 0 && len(kind) > 0 {
+		obj["kind"] = kind
+	}
+	if v, _ := obj["apiVersion"].(string); len(v) == 0 && len(apiVersion) > 0 {
+		obj["apiVersion"] = apiVersion
+	}
+}
+
+// dedupe drops objects that were read from more than one dump, keeping the
+// copy with the newest resourceVersion. Objects are matched by kind and UID;
+// objects without a UID are all kept. The order of first appearance is kept.
+func dedupe(objects []map[string]interface{}) []map[string]interface{} {
+	deduped := []map[string]interface{}{}
+	seen := map[string]int{}
+	for _, obj := range objects {
+		uid, version := objectVersion(obj)
+		if len(uid) == 0 {
+			deduped = append(deduped, obj)
+			continue
+		}
+		kind, _ := obj["kind"].(string)
+		key := kind + "/" + uid
+		index, ok := seen[key]
+		if !ok {
+			seen[key] = len(deduped)
+			deduped = append(deduped, obj)
+			continue
+		}
+		if _, current := objectVersion(deduped[index]); newerVersion(version, current) {
+			deduped[index] = obj
+		}
+	}
+	return deduped
+}
+
+func objectVersion(obj map[string]interface{}) (string, string) {
+	metadata, ok := obj["metadata"].(map[string]interface{})
+	if !ok {
+		return "", ""
+	}
+	uid, _ := metadata["uid"].(string)
+	version, _ := metadata["resourceVersion"].(string)
+	return uid, version
+}
+
+// newerVersion reports whether resourceVersion a is newer than b. Resource
+// versions are opaque, but etcd backed clusters use increasing integers.
+func newerVersion(a string, b string) bool {
+	x, errA := strconv.ParseUint(a, 10, 64)
+	y, errB := strconv.ParseUint(b, 10, 64)
+	if errA != nil || errB != nil {
+		return len(a) > len(b) || (len(a) == len(b) && a > b)
+	}
+	return x > y
+}
+
+// logs returns the log section marked by marker in logFile, or the whole
+// file if marker is empty. Files holding log sections are indexed so that
+// later reads go straight to the section.
+func (s *source) logs(logFile string, marker string) (io.ReadCloser, error) {
+	if len(marker) > 0 {
+		file := s.lookupIndex(logFile)
+		if file == nil && s.canIndex(logFile) {
+			file = s.buildIndex(logFile)
+		}
+		if file != nil {
+			if r, ok := s.indexedLogs(logFile, file, marker); ok {
+				return r, nil
+			}
+		}
+	}
+	r, err := s.open(logFile)
+	if err != nil || len(marker) == 0 {
+		return r, err
+	}
+	return &readCloser{Reader: &sectionReader{reader: bufio.NewReader(r), marker: marker}, close: r.Close}, nil
+}
+
+// matchesMarker reports whether line, the START or END line of a log
+// section, ends with marker, such as " of pod default/web ====" for all the
+// containers of pod web or " for container c of pod default/web ====" for
+// one of them. Matching the end of the line keeps the sections of pod web-1
+// out of those of pod web.
+func matchesMarker(line string, marker string) bool {
+	return strings.HasSuffix(strings.TrimRight(line, " \r\n"), marker)
+}
+
+// sectionReader passes on the "==== START logs" sections of a dump whose
+// START line matches marker, up to and including their END line.
+type sectionReader struct {
+	reader  *bufio.Reader
+	marker  string
+	inside  bool
+	pending []byte
+	err     error
+}
+
+func (r *sectionReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		line, err := r.reader.ReadBytes('\n')
+		r.err = err
+		if len(line) == 0 {
+			continue
+		}
+		if line[len(line)-1] != '\n' {
+			line = append(line, '\n')
+		}
+		if bytes.HasPrefix(line, []byte(logStartMarker)) && matchesMarker(string(line), r.marker) {
+			r.inside = true
+		}
+		if r.inside {
+			r.pending = line
+		}
+		if bytes.HasPrefix(line, []byte(logEndMarker)) && matchesMarker(string(line), r.marker) {
+			r.inside = false
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
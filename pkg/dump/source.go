@@ -0,0 +1,183 @@
+package dump
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// StdinPath is the path Open reads the dump from stdin for.
+	StdinPath = "-"
+	stdinName = "stdin"
+)
+
+// source is one of the dumps a Dump is read from.
+type source struct {
+	dump *Dump
+	// name is the path the dump was given as.
+	name string
+	// fsys is the file system the dump is read from: the directory the dump
+	// lives in, or the members of an archive.
+	fsys fs.FS
+	// root is the path of the dump inside fsys, laid out as layout.
+	root   string
+	layout layoutKind
+	// indexPath is where the index of the dump is kept, or empty if the dump
+	// is not indexed.
+	indexPath string
+	index     *dumpIndex
+	// newest is the newest timestamp in each file read so far.
+	newest map[string]time.Time
+}
+
+// openSource opens the dump at location and detects its layout from its
+// contents. A tar or zip archive is read like a directory, and "-" reads the
+// dump from stdin.
+func (d *Dump) openSource(location string) (*source, error) {
+	s := &source{dump: d, name: location, root: ".", newest: map[string]time.Time{}}
+	if location == StdinPath {
+		f, err := spoolStdin()
+		if err != nil {
+			return nil, fmt.Errorf("reading dump from stdin: %w", err)
+		}
+		d.closers = append(d.closers, f)
+		s.name = stdinName
+		archive, err := openArchive(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading archive from stdin: %w", err)
+		}
+		if archive != nil {
+			s.fsys = archive
+		} else {
+			s.fsys = &streamFS{file: f}
+			s.root = stdinName
+		}
+		return s, s.detectLayout()
+	}
+	info, err := os.Stat(location)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		s.fsys = os.DirFS(location)
+		s.indexPath = filepath.Join(location, indexFileName)
+		return s, s.detectLayout()
+	}
+	f, err := os.Open(location)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := openArchive(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("reading archive %s: %w", location, err)
+	}
+	if archive != nil {
+		d.closers = append(d.closers, f)
+		s.fsys = archive
+	} else {
+		f.Close()
+		s.fsys = os.DirFS(filepath.Dir(location))
+		s.root = filepath.Base(location)
+		s.indexPath = location + indexFileName
+	}
+	return s, s.detectLayout()
+}
+
+func (s *source) detectLayout() error {
+	layout, root, err := detectLayout(s.fsys, s.root)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", s.name, err)
+	}
+	s.layout, s.root = layout, root
+	return nil
+}
+
+// spoolStdin copies stdin to a temporary file, so that commands which read
+// the dump more than once, and archives which need random access, work on a
+// pipe. The file is unlinked right away and goes away with the process.
+func spoolStdin() (*os.File, error) {
+	f, err := os.CreateTemp("", "kubedmp-stdin-")
+	if err != nil {
+		return nil, err
+	}
+	os.Remove(f.Name())
+	if _, err := io.Copy(f, os.Stdin); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// streamFS is a file system holding only the dump read from stdin.
+type streamFS struct {
+	file *os.File
+}
+
+func (s *streamFS) Open(name string) (fs.File, error) {
+	if name != stdinName {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	info, err := s.file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return &streamFile{SectionReader: io.NewSectionReader(s.file, 0, info.Size()), info: info}, nil
+}
+
+type streamFile struct {
+	*io.SectionReader
+	info fs.FileInfo
+}
+
+func (f *streamFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *streamFile) Close() error               { return nil }
+
+// open opens name in the dump, decompressing it if needed.
+func (s *source) open(name string) (io.ReadCloser, error) {
+	r, _, err := s.openFile(name)
+	return r, err
+}
+
+// openFile is open that also reports whether the file is compressed.
+func (s *source) openFile(name string) (io.ReadCloser, bool, error) {
+	f, err := s.fsys.Open(name)
+	if err != nil {
+		return nil, false, err
+	}
+	r, compressed, err := decompress(f)
+	if err != nil {
+		f.Close()
+		return nil, compressed, err
+	}
+	return &readCloser{Reader: r, close: func() error {
+		r.Close()
+		return f.Close()
+	}}, compressed, nil
+}
+
+// filePath returns the path of the dump file name in dir, with the
+// extension of whichever supported format it was written in.
+func (s *source) filePath(dir string, name string) string {
+	for _, ext := range dumpFileExtensions {
+		filePath := path.Join(dir, name+ext)
+		if _, err := fs.Stat(s.fsys, filePath); err == nil {
+			return filePath
+		}
+	}
+	return path.Join(dir, name+dumpFileExtensions[0])
+}
+
+// location names filePath for messages, prefixed by the dump it is in when
+// the dump is a directory or an archive.
+func (s *source) location(filePath string) string {
+	if s.layout == layoutFile && filePath == s.root {
+		return s.name
+	}
+	return s.name + ":" + filePath
+}
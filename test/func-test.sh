@@ -33,6 +33,12 @@ test_res () {
     test_command "go run cmd/main.go $DUMP describe $1 $item -n $ns"
 }
 
+test_cluster_res () {
+    test_command "go run cmd/main.go $DUMP get $1"
+    item=$(echo $list | cut -f1 -d' ')
+    test_command "go run cmd/main.go $DUMP describe $1 $item"
+}
+
 test_command "go run cmd/main.go $DUMP show"
 test_command "go run cmd/main.go $DUMP get no"
 item=$(echo $list | cut -f1 -d' ')
@@ -70,4 +76,49 @@ test_res "ep"
 test_res "job"
 test_res "cronjob"
 test_res "role"
-test_res "rolebinding"
\ No newline at end of file
+test_res "rolebinding"
+
+test_command "go run cmd/main.go $DUMP get po -A --show-source"
+
+test_command "go run cmd/main.go $DUMP get po -A"
+ns=$(echo $list | cut -f1 -d' ')
+item=$(echo $list | cut -f2 -d' ')
+test_command "go run cmd/main.go $DUMP logs $item -n $ns"
+
+test_command "go run cmd/main.go $DUMP get po -A --as-of 2030-01-01T00:00:00Z"
+
+test_command "go run cmd/main.go $DUMP get po -A -o wide"
+test_command "go run cmd/main.go $DUMP get po -A -o name"
+test_command "go run cmd/main.go $DUMP get po -A -o yaml"
+test_command "go run cmd/main.go $DUMP get po -A -o jsonpath='{.items[*].metadata.name}'"
+test_command "go run cmd/main.go $DUMP get po -A --show-labels"
+test_command "go run cmd/main.go $DUMP get po -A -L app"
+test_command "go run cmd/main.go $DUMP get po -A --sort-by=.metadata.name"
+
+test_command "go run cmd/main.go $DUMP get all -A"
+test_command "go run cmd/main.go $DUMP get po,svc -A"
+
+test_cluster_res "ns"
+
+test_command "go run cmd/main.go $DUMP describe po -A"
+test_command "go run cmd/main.go $DUMP describe po -A --as-of 2030-01-01T00:00:00Z"
+
+test_cluster_res "pc"
+test_res "hpa"
+test_res "pdb"
+test_res "quota"
+test_res "limits"
+
+test_cluster_res "ingressclass"
+test_res "netpol"
+test_res "endpointslices"
+
+test_cluster_res "validatingwebhookconfigurations"
+test_cluster_res "mutatingwebhookconfigurations"
+test_cluster_res "apiservices"
+
+test_cluster_res "csidrivers"
+test_cluster_res "csinodes"
+test_cluster_res "volumeattachments"
+test_cluster_res "vsc"
+test_res "vs"